@@ -4,23 +4,102 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
 )
 
 type HTTPClient struct {
-	config     *x10.Config
-	httpClient *http.Client
-	apiKey     string
+	baseURL     string
+	userAgent   string
+	httpClient  *http.Client
+	apiKey      string
+	retryPolicy RetryPolicy
+	limiter     *TokenBucket
+	logger      *slog.Logger
+}
+
+func NewHTTPClient(cfg *x10.Config, opts ...Option) *HTTPClient {
+	return NewHTTPClientWithAPIKey(cfg, "", opts...)
+}
+
+func NewHTTPClientWithAPIKey(cfg *x10.Config, apiKey string, opts ...Option) *HTTPClient {
+	o := ResolveOptions(cfg, opts...)
+	return &HTTPClient{
+		baseURL:     o.BaseURL,
+		userAgent:   o.UserAgent,
+		httpClient:  o.HTTPClient,
+		apiKey:      apiKey,
+		retryPolicy: DefaultRetryPolicy(),
+		limiter:     o.Limiter,
+		logger:      o.Logger,
+	}
+}
+
+// SetRetryPolicy replaces the retry policy used for subsequent requests.
+func (c *HTTPClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetLogger replaces the logger used for request diagnostics. A nil logger discards them.
+func (c *HTTPClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	c.logger = logger
+}
+
+// SetRateLimiter replaces the client-side rate limiter. A nil limiter disables client-side limiting.
+// By default each client has its own limiter tuned to DefaultRequestsPerMinute (see WithRateLimiter).
+func (c *HTTPClient) SetRateLimiter(limiter *TokenBucket) {
+	c.limiter = limiter
 }
 
 func (c *HTTPClient) Post(ctx context.Context, endpoint string, payload interface{}, result interface{}) error {
-	url := c.config.APIBaseURL + endpoint
+	return c.do(ctx, "POST", endpoint, nil, payload, result, false)
+}
+
+// PostIdempotent is Post for payloads that are safe to resubmit, such as a signed order: the payload
+// is marshalled once, so every attempt carries the same order ID and nonce and the exchange rejects a
+// duplicate instead of executing it twice. It is retried on 5xx responses and network errors as well.
+// A retry after a lost response can therefore fail with a duplicate-order rejection although the
+// first attempt was accepted; such failures are wrapped in *RetriedError.
+func (c *HTTPClient) PostIdempotent(ctx context.Context, endpoint string, payload interface{}, result interface{}) error {
+	return c.do(ctx, "POST", endpoint, nil, payload, result, true)
+}
+
+func (c *HTTPClient) Get(ctx context.Context, endpoint string, result interface{}) error {
+	return c.do(ctx, "GET", endpoint, nil, nil, result, true)
+}
+
+func (c *HTTPClient) Patch(ctx context.Context, endpoint string, payload interface{}, result interface{}) error {
+	return c.do(ctx, "PATCH", endpoint, nil, payload, result, false)
+}
+
+func (c *HTTPClient) Delete(ctx context.Context, endpoint string, result interface{}) error {
+	return c.do(ctx, "DELETE", endpoint, nil, nil, result, false)
+}
+
+// PostWithHeader is Post with extra request headers, such as the L1 signature used by onboarding endpoints.
+// Header names are sent exactly as given.
+func (c *HTTPClient) PostWithHeader(ctx context.Context, endpoint string, header http.Header, payload interface{}, result interface{}) error {
+	return c.do(ctx, "POST", endpoint, header, payload, result, false)
+}
+
+// GetWithHeader is Get with extra request headers. Header names are sent exactly as given.
+func (c *HTTPClient) GetWithHeader(ctx context.Context, endpoint string, header http.Header, result interface{}) error {
+	return c.do(ctx, "GET", endpoint, header, nil, result, true)
+}
 
+// do sends a request, retrying it according to the retry policy, and decodes the response into result.
+// idempotent requests are also retried on 5xx responses and network errors; all requests are retried on 429.
+func (c *HTTPClient) do(ctx context.Context, method string, endpoint string, header http.Header, payload interface{}, result interface{}, idempotent bool) error {
 	var bodyBytes []byte
 	var err error
 	if payload != nil {
@@ -30,150 +109,208 @@ func (c *HTTPClient) Post(ctx context.Context, endpoint string, payload interfac
 		}
 	}
 
-	// Debug: print order POST body for comparison
-	if endpoint == "/user/order" {
-		fmt.Printf("Go Order JSON: %s\n", string(bodyBytes))
+	maxAttempts := c.retryPolicy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "X10GoSDK/1.0")
-	req.Header.Set("Content-Type", "application/json")
-	if c.apiKey != "" {
-		req.Header.Set("X-Api-Key", c.apiKey)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Printf("Error response body: %s\n", string(body))
-		return fmt.Errorf("request failed with status: %d", resp.StatusCode)
-	}
+		err = c.attempt(ctx, method, endpoint, header, bodyBytes, result)
+		if err == nil {
+			return nil
+		}
+		if attempt >= maxAttempts {
+			return retried(err, attempt)
+		}
 
-	if result == nil {
-		io.Copy(io.Discard, resp.Body)
-		return nil
-	}
+		delay, retry := c.retryDelay(ctx, err, attempt, idempotent)
+		if !retry {
+			return retried(err, attempt)
+		}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "retrying request",
+			slog.String("method", method),
+			slog.String("endpoint", endpoint),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", delay),
+			slog.Any("error", err),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return retried(err, attempt)
+		}
 	}
+}
 
-	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+// RetriedError wraps the error of a request that was sent more than once. For PostIdempotent requests
+// an earlier attempt may have been executed by the exchange although its response was lost.
+type RetriedError struct {
+	Attempts int
+	Err      error
+}
 
-	return nil
+func (e *RetriedError) Error() string {
+	return fmt.Sprintf("%v (after %d attempts)", e.Err, e.Attempts)
 }
 
-func NewHTTPClient(cfg *x10.Config) *HTTPClient {
-	return &HTTPClient{
-		config: cfg,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}
+func (e *RetriedError) Unwrap() error {
+	return e.Err
 }
 
-func NewHTTPClientWithAPIKey(cfg *x10.Config, apiKey string) *HTTPClient {
-	return &HTTPClient{
-		config: cfg,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		apiKey: apiKey,
+// retried wraps err in *RetriedError when the request was sent more than once.
+func retried(err error, attempts int) error {
+	if attempts <= 1 {
+		return err
 	}
+	return &RetriedError{Attempts: attempts, Err: err}
 }
 
-func (c *HTTPClient) Get(ctx context.Context, endpoint string, result interface{}) error {
-	url := c.config.APIBaseURL + endpoint
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// retryDelay decides whether a failed attempt is retried and how long to wait first.
+func (c *HTTPClient) retryDelay(ctx context.Context, err error, attempt int, idempotent bool) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
 	}
 
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "X10GoSDK/1.0")
-
-	if c.apiKey != "" {
-		req.Header.Set("X-Api-Key", c.apiKey)
+	var rateLimited *models.RateLimitError
+	if errors.As(err, &rateLimited) {
+		if rateLimited.RetryAfter <= 0 {
+			return c.retryPolicy.backoff(attempt), true
+		}
+		// Retrying earlier than asked would be rejected again, so a longer wait is left to the caller
+		if c.retryPolicy.MaxBackoff > 0 && rateLimited.RetryAfter > c.retryPolicy.MaxBackoff {
+			return 0, false
+		}
+		return rateLimited.RetryAfter, true
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+	if !idempotent {
+		return 0, false
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed with status: %d", resp.StatusCode)
+	var apiErr *models.X10Error
+	if errors.As(err, &apiErr) {
+		if apiErr.HTTPStatus >= 500 {
+			return c.retryPolicy.backoff(attempt), true
+		}
+		return 0, false
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+	var netErr *transportError
+	if errors.As(err, &netErr) {
+		return c.retryPolicy.backoff(attempt), true
 	}
+	return 0, false
+}
 
-	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+// transportError marks failures to get any response from the server.
+type transportError struct {
+	err error
+}
 
-	return nil
+func (e *transportError) Error() string {
+	return fmt.Sprintf("failed to make request: %v", e.err)
 }
 
-func (c *HTTPClient) Patch(ctx context.Context, endpoint string, payload interface{}, result interface{}) error {
-	url := c.config.APIBaseURL + endpoint
+func (e *transportError) Unwrap() error {
+	return e.err
+}
 
-	var bodyBytes []byte
-	var err error
-	if payload != nil {
-		bodyBytes, err = json.Marshal(payload)
-		if err != nil {
-			return fmt.Errorf("failed to marshal payload: %w", err)
-		}
+// attempt sends a single request.
+// Failed requests are returned as the typed errors from the models package (see models.NewAPIError).
+func (c *HTTPClient) attempt(ctx context.Context, method string, endpoint string, header http.Header, bodyBytes []byte, result interface{}) error {
+	url := c.baseURL + endpoint
+
+	var reqBody io.Reader
+	if method == "POST" || method == "PATCH" {
+		reqBody = bytes.NewReader(bodyBytes)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "X10GoSDK/1.0")
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	if c.apiKey != "" {
 		req.Header.Set("X-Api-Key", c.apiKey)
 	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
 
+	debug := c.logger.Enabled(ctx, slog.LevelDebug)
+	if debug {
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "sending request",
+			slog.String("method", method),
+			slog.String("url", url),
+			slog.Any("headers", redactHeaders(req.Header)),
+			slog.String("body", redactBody(bodyBytes)),
+		)
+	}
+
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "request failed",
+			slog.String("method", method),
+			slog.String("url", url),
+			slog.Any("error", err),
+		)
+		return &transportError{err: err}
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed with status: %d", resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if debug {
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "received response",
+			slog.String("method", method),
+			slog.String("url", url),
+			slog.Int("status", resp.StatusCode),
+			slog.Duration("elapsed", time.Since(start)),
+			slog.String("body", redactBody(body)),
+		)
 	}
 
-	if result == nil {
-		io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "error response",
+			slog.String("method", method),
+			slog.String("url", url),
+			slog.Int("status", resp.StatusCode),
+			slog.String("body", redactBody(body)),
+		)
+		return models.NewAPIError(resp.StatusCode, resp.Header, body)
+	}
+
+	// Cancellation and update endpoints may reply with an empty body
+	if result == nil || len(bytes.TrimSpace(body)) == 0 {
 		return nil
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+	// The exchange may report a failure inside a successful HTTP response
+	var envelope struct {
+		Status string          `json:"status"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Status == "ERROR" && len(envelope.Error) > 0 {
+		return models.NewAPIError(resp.StatusCode, resp.Header, body)
 	}
 
 	if err := json.Unmarshal(body, result); err != nil {
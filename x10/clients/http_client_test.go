@@ -0,0 +1,209 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
+)
+
+// scriptedServer answers the n-th request with statuses[n-1], repeating the last one.
+func scriptedServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		status := statuses[min(n, len(statuses))-1]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			fmt.Fprint(w, `{"status":"OK"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"ERROR","error":{"code":%d,"message":"failed"}}`, status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func testHTTPClient(baseURL string) *HTTPClient {
+	c := NewHTTPClient(x10.Testnet(), WithBaseURL(baseURL))
+	c.SetRateLimiter(nil)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2})
+	return c
+}
+
+func TestHTTPClientRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		send      func(c *HTTPClient, result any) error
+		wantCalls int32
+		wantErr   bool
+		retried   bool
+	}{
+		{
+			name:      "get retried on 5xx",
+			statuses:  []int{http.StatusBadGateway, http.StatusOK},
+			send:      func(c *HTTPClient, result any) error { return c.Get(context.Background(), "/x", result) },
+			wantCalls: 2,
+		},
+		{
+			name:      "post not retried on 5xx",
+			statuses:  []int{http.StatusBadGateway, http.StatusOK},
+			send:      func(c *HTTPClient, result any) error { return c.Post(context.Background(), "/x", struct{}{}, result) },
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "post retried on 429",
+			statuses:  []int{http.StatusTooManyRequests, http.StatusOK},
+			send:      func(c *HTTPClient, result any) error { return c.Post(context.Background(), "/x", struct{}{}, result) },
+			wantCalls: 2,
+		},
+		{
+			name:     "idempotent post rejected on retry",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusBadRequest},
+			send: func(c *HTTPClient, result any) error {
+				return c.PostIdempotent(context.Background(), "/x", struct{}{}, result)
+			},
+			wantCalls: 2,
+			wantErr:   true,
+			retried:   true,
+		},
+		{
+			name:      "attempts exhausted",
+			statuses:  []int{http.StatusInternalServerError},
+			send:      func(c *HTTPClient, result any) error { return c.Get(context.Background(), "/x", result) },
+			wantCalls: 3,
+			wantErr:   true,
+			retried:   true,
+		},
+		{
+			name:      "client error not retried",
+			statuses:  []int{http.StatusBadRequest, http.StatusOK},
+			send:      func(c *HTTPClient, result any) error { return c.Get(context.Background(), "/x", result) },
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := scriptedServer(t, tt.statuses...)
+			var result struct {
+				Status string `json:"status"`
+			}
+			err := tt.send(testHTTPClient(srv.URL), &result)
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if result.Status != "OK" {
+					t.Errorf("status = %q, want OK", result.Status)
+				}
+				return
+			}
+
+			var apiErr *models.X10Error
+			if !errors.As(err, &apiErr) {
+				t.Errorf("got %T, want an *models.X10Error in the chain", err)
+			}
+			var retriedErr *RetriedError
+			if errors.As(err, &retriedErr) != tt.retried {
+				t.Errorf("RetriedError in chain = %v, want %v", !tt.retried, tt.retried)
+			}
+			if tt.retried && retriedErr.Attempts != int(tt.wantCalls) {
+				t.Errorf("Attempts = %d, want %d", retriedErr.Attempts, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestHTTPClientRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string // Retry-After of the first, rate-limited response
+		wantCalls  int32
+		wantWait   time.Duration // minimum time the request takes
+	}{
+		{name: "honoured in full", retryAfter: "1", wantCalls: 2, wantWait: time.Second},
+		{name: "absent, backoff", wantCalls: 2},
+		{name: "longer than max backoff", retryAfter: "30", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				fmt.Fprint(w, `{"status":"OK"}`)
+			}))
+			t.Cleanup(srv.Close)
+			c := testHTTPClient(srv.URL)
+			c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second, Multiplier: 2})
+
+			start := time.Now()
+			err := c.Get(context.Background(), "/x", nil)
+			elapsed := time.Since(start)
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if elapsed < tt.wantWait {
+				t.Errorf("request took %s, want at least %s", elapsed, tt.wantWait)
+			}
+			if tt.wantCalls > 1 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			var rateLimited *models.RateLimitError
+			if !errors.As(err, &rateLimited) {
+				t.Fatalf("got %v, want *models.RateLimitError", err)
+			}
+			if rateLimited.RetryAfter != 30*time.Second {
+				t.Errorf("RetryAfter = %s, want 30s", rateLimited.RetryAfter)
+			}
+			if elapsed > time.Second {
+				t.Errorf("request took %s, want the error without waiting", elapsed)
+			}
+		})
+	}
+}
+
+func TestHTTPClientRateLimiter(t *testing.T) {
+	a := NewHTTPClient(x10.Testnet())
+	b := NewHTTPClient(x10.Testnet())
+	if a.limiter == nil || a.limiter == b.limiter {
+		t.Error("clients created without WithRateLimiter must each get their own limiter")
+	}
+
+	shared := NewTokenBucket(60, 1)
+	a = NewHTTPClient(x10.Testnet(), WithRateLimiter(shared))
+	b = NewHTTPClient(x10.Testnet(), WithRateLimiter(shared))
+	if a.limiter != shared || b.limiter != shared {
+		t.Error("clients created with WithRateLimiter must use the given limiter")
+	}
+}
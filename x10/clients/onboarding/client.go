@@ -0,0 +1,273 @@
+package onboarding
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+)
+
+// Headers authenticating onboarding requests with the L1 key.
+const (
+	L1AuthSignatureHeader = "L1_SIGNATURE"
+	L1MessageTimeHeader   = "L1_MESSAGE_TIME"
+	ActiveAccountHeader   = "X-X10-ACTIVE-ACCOUNT"
+)
+
+// OnboardedAccount is an account of the L1 wallet together with its derived Stark key pair.
+type OnboardedAccount struct {
+	Account   user.SubAccount
+	L2KeyPair StarkKeyPair
+}
+
+// StarknetAccount returns a ready-to-use trading account for the onboarded account, signing with
+// its derived Stark key and authenticating with apiKey.
+func (a *OnboardedAccount) StarknetAccount(apiKey string) (*starknet.StarknetPerpetualAccount, error) {
+	signer, err := starknet.NewPrivateKeySigner(a.L2KeyPair.Private)
+	if err != nil {
+		return nil, err
+	}
+	return starknet.NewStarknetAccountWithSigner(a.Account.L2Vault, apiKey, signer)
+}
+
+// UserClient onboards accounts for an L1 (Ethereum) wallet and manages their API keys.
+// It is the Go equivalent of the Python SDK's UserClient. Stark keys are derived from the L1 key,
+// so the same wallet always yields the same accounts.
+type UserClient struct {
+	httpClient *clients.HTTPClient
+	config     *x10.Config
+	l1Key      *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewUserClient creates a client for the onboarding endpoints of cfg that signs with the hex
+// encoded Ethereum private key l1PrivateKey.
+func NewUserClient(cfg *x10.Config, l1PrivateKey string, opts ...clients.Option) (*UserClient, error) {
+	l1Key, err := crypto.HexToECDSA(strings.TrimPrefix(l1PrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid l1 private key: %w", err)
+	}
+
+	opts = append([]clients.Option{clients.WithBaseURL(cfg.OnboardingURL)}, opts...)
+	return &UserClient{
+		httpClient: clients.NewHTTPClient(cfg, opts...),
+		config:     cfg,
+		l1Key:      l1Key,
+		address:    crypto.PubkeyToAddress(l1Key.PublicKey),
+	}, nil
+}
+
+// Address returns the L1 wallet address.
+func (c *UserClient) Address() string {
+	return c.address.Hex()
+}
+
+// SetRetryPolicy sets how failed REST requests are retried. See clients.RetryPolicy.
+func (c *UserClient) SetRetryPolicy(policy clients.RetryPolicy) {
+	c.httpClient.SetRetryPolicy(policy)
+}
+
+// SetLogger sets the logger for REST diagnostics; nil discards them.
+func (c *UserClient) SetLogger(logger *slog.Logger) {
+	c.httpClient.SetLogger(logger)
+}
+
+// DeriveL2KeyPair derives the Stark key pair of the account with accountIndex.
+func (c *UserClient) DeriveL2KeyPair(accountIndex int) (StarkKeyPair, error) {
+	if accountIndex < 0 || accountIndex > 127 {
+		return StarkKeyPair{}, fmt.Errorf("account index must be between 0 and 127")
+	}
+	return DeriveL2KeyPair(c.l1Key, accountIndex, c.config.SigningDomain)
+}
+
+// Onboard registers the L1 wallet with the exchange and returns its default account (index 0).
+func (c *UserClient) Onboard(ctx context.Context, referralCode *string) (*OnboardedAccount, error) {
+	keyPair, err := c.DeriveL2KeyPair(0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := NewOnboardingRequest(c.l1Key, c.config.SigningDomain, keyPair, time.Now(), referralCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Status string                `json:"status"`
+		Data   *user.OnboardedClient `json:"data"`
+	}
+
+	if err := c.httpClient.Post(ctx, "/auth/onboard", req, &response); err != nil {
+		return nil, fmt.Errorf("failed to onboard: %w", err)
+	}
+	if response.Data == nil {
+		return nil, fmt.Errorf("failed to onboard: no account data returned")
+	}
+
+	return &OnboardedAccount{Account: response.Data.DefaultAccount, L2KeyPair: keyPair}, nil
+}
+
+// OnboardSubAccount creates the account with accountIndex. If it already exists, the existing account
+// is returned. description defaults to "Subaccount <index>".
+func (c *UserClient) OnboardSubAccount(ctx context.Context, accountIndex int, description *string) (*OnboardedAccount, error) {
+	const requestPath = "/auth/onboard/subaccount"
+
+	keyPair, err := c.DeriveL2KeyPair(accountIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := fmt.Sprintf("Subaccount %d", accountIndex)
+	if description != nil {
+		desc = *description
+	}
+
+	req, err := NewSubAccountOnboardingRequest(c.address, accountIndex, keyPair, desc, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := c.authHeader(requestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Status string           `json:"status"`
+		Data   *user.SubAccount `json:"data"`
+	}
+
+	err = c.httpClient.PostWithHeader(ctx, requestPath, header, req, &response)
+	var apiErr *models.X10Error
+	if errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusConflict {
+		return c.findAccount(ctx, accountIndex)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to onboard sub-account: %w", err)
+	}
+	if response.Data == nil {
+		return nil, fmt.Errorf("failed to onboard sub-account: no account data returned")
+	}
+
+	return &OnboardedAccount{Account: *response.Data, L2KeyPair: keyPair}, nil
+}
+
+// GetAccounts returns all accounts of the L1 wallet with their derived Stark key pairs.
+func (c *UserClient) GetAccounts(ctx context.Context) ([]OnboardedAccount, error) {
+	const requestPath = "/api/v1/user/accounts"
+
+	header, err := c.authHeader(requestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Status string            `json:"status"`
+		Data   []user.SubAccount `json:"data"`
+	}
+
+	if err := c.httpClient.GetWithHeader(ctx, requestPath, header, &response); err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	accounts := make([]OnboardedAccount, 0, len(response.Data))
+	for _, account := range response.Data {
+		keyPair, err := c.DeriveL2KeyPair(account.AccountIndex)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, OnboardedAccount{Account: account, L2KeyPair: keyPair})
+	}
+	return accounts, nil
+}
+
+// CreateAPIKey creates a trading API key for account. description defaults to
+// "trading api key for account <id>".
+func (c *UserClient) CreateAPIKey(ctx context.Context, account user.SubAccount, description *string) (string, error) {
+	const requestPath = "/api/v1/user/account/api-key"
+
+	desc := fmt.Sprintf("trading api key for account %d", account.ID)
+	if description != nil {
+		desc = *description
+	}
+
+	header, err := c.authHeader(requestPath)
+	if err != nil {
+		return "", err
+	}
+	header[ActiveAccountHeader] = []string{strconv.Itoa(account.ID)}
+
+	var response struct {
+		Status string               `json:"status"`
+		Data   *user.APIKeyResponse `json:"data"`
+	}
+
+	if err := c.httpClient.PostWithHeader(ctx, requestPath, header, user.APIKeyRequest{Description: desc}, &response); err != nil {
+		return "", fmt.Errorf("failed to create api key: %w", err)
+	}
+	if response.Data == nil || response.Data.Key == "" {
+		return "", fmt.Errorf("failed to create api key: no key returned")
+	}
+	return response.Data.Key, nil
+}
+
+// CreateStarknetAccount onboards the account with accountIndex (the wallet itself for index 0),
+// creates an API key for it and returns it as a ready-to-use trading account.
+func (c *UserClient) CreateStarknetAccount(ctx context.Context, accountIndex int, description *string) (*starknet.StarknetPerpetualAccount, error) {
+	var onboarded *OnboardedAccount
+	var err error
+	if accountIndex == 0 {
+		onboarded, err = c.Onboard(ctx, nil)
+	} else {
+		onboarded, err = c.OnboardSubAccount(ctx, accountIndex, description)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := c.CreateAPIKey(ctx, onboarded.Account, nil)
+	if err != nil {
+		return nil, err
+	}
+	return onboarded.StarknetAccount(apiKey)
+}
+
+// findAccount returns the existing account with accountIndex.
+func (c *UserClient) findAccount(ctx context.Context, accountIndex int) (*OnboardedAccount, error) {
+	accounts, err := c.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range accounts {
+		if accounts[i].Account.AccountIndex == accountIndex {
+			return &accounts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("sub-account %d already exists but was not found in the wallet's accounts", accountIndex)
+}
+
+// authHeader signs "<requestPath>@<time>" with the L1 key, authenticating a request to requestPath.
+func (c *UserClient) authHeader(requestPath string) (http.Header, error) {
+	authTime := time.Now().UTC().Format(timeLayout)
+	signature, err := signPersonalMessage(c.l1Key, requestPath+"@"+authTime)
+	if err != nil {
+		return nil, err
+	}
+	return http.Header{
+		L1AuthSignatureHeader: {signature},
+		L1MessageTimeHeader:   {authTime},
+	}, nil
+}
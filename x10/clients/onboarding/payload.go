@@ -0,0 +1,192 @@
+package onboarding
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+)
+
+// Registration actions signed in AccountRegistration.
+const (
+	ActionRegister         = "REGISTER"
+	ActionCreateSubAccount = "CREATE_SUB_ACCOUNT"
+)
+
+// timeLayout formats the registration and request authentication times.
+const timeLayout = "2006-01-02T15:04:05Z"
+
+var (
+	eip712DomainType        = []byte("EIP712Domain(string name)")
+	accountCreationType     = []byte("AccountCreation(int8 accountIndex,address wallet,bool tosAccepted)")
+	accountRegistrationType = []byte("AccountRegistration(int8 accountIndex,address wallet,bool tosAccepted,string time,string action)")
+)
+
+// StarkKeyPair is the L2 key pair of an account, derived from the L1 key.
+type StarkKeyPair struct {
+	Private *big.Int
+	Public  *big.Int
+}
+
+// PublicHex returns the public key as a 0x-prefixed hex string.
+func (k StarkKeyPair) PublicHex() string {
+	return fmt.Sprintf("0x%x", k.Public)
+}
+
+// String keeps the private key out of fmt output.
+func (k StarkKeyPair) String() string {
+	return fmt.Sprintf("StarkKeyPair{public: 0x%x}", k.Public)
+}
+
+// DeriveL2KeyPair derives the Stark key pair of the account with accountIndex from the L1 key:
+// the L1 key signs the EIP-712 AccountCreation message and the signature's r is ground into a Stark key.
+func DeriveL2KeyPair(l1Key *ecdsa.PrivateKey, accountIndex int, signingDomain string) (StarkKeyPair, error) {
+	address := crypto.PubkeyToAddress(l1Key.PublicKey)
+	structHash := crypto.Keccak256(
+		crypto.Keccak256(accountCreationType),
+		encodeInt8(accountIndex),
+		common.LeftPadBytes(address.Bytes(), 32),
+		encodeBool(true),
+	)
+
+	signature, err := signTypedData(l1Key, signingDomain, structHash)
+	if err != nil {
+		return StarkKeyPair{}, err
+	}
+
+	private, err := starknet.PrivateKeyFromEthSignature(signature)
+	if err != nil {
+		return StarkKeyPair{}, err
+	}
+	signer, err := starknet.NewPrivateKeySigner(private)
+	if err != nil {
+		return StarkKeyPair{}, fmt.Errorf("failed to derive stark key: %w", err)
+	}
+	return StarkKeyPair{Private: private, Public: signer.PublicKey()}, nil
+}
+
+// NewOnboardingRequest builds the signed request that registers the L1 wallet and its default account.
+func NewOnboardingRequest(l1Key *ecdsa.PrivateKey, signingDomain string, keyPair StarkKeyPair, at time.Time, referralCode *string) (*user.OnboardingRequest, error) {
+	address := crypto.PubkeyToAddress(l1Key.PublicKey)
+	registration := newRegistration(0, address, at, ActionRegister)
+
+	l1Signature, err := signTypedData(l1Key, signingDomain, registrationHash(registration, address))
+	if err != nil {
+		return nil, err
+	}
+	l2Signature, err := signL1Address(address, keyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.OnboardingRequest{
+		L1Signature:     l1Signature,
+		L2Key:           keyPair.PublicHex(),
+		L2Signature:     l2Signature,
+		AccountCreation: registration,
+		ReferralCode:    referralCode,
+	}, nil
+}
+
+// NewSubAccountOnboardingRequest builds the signed request that creates the account with accountIndex.
+func NewSubAccountOnboardingRequest(l1Address common.Address, accountIndex int, keyPair StarkKeyPair, description string, at time.Time) (*user.SubAccountOnboardingRequest, error) {
+	registration := newRegistration(accountIndex, l1Address, at, ActionCreateSubAccount)
+
+	l2Signature, err := signL1Address(l1Address, keyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.SubAccountOnboardingRequest{
+		L2Key:           keyPair.PublicHex(),
+		L2Signature:     l2Signature,
+		AccountCreation: registration,
+		Description:     description,
+	}, nil
+}
+
+func newRegistration(accountIndex int, address common.Address, at time.Time, action string) user.AccountRegistration {
+	return user.AccountRegistration{
+		AccountIndex: accountIndex,
+		Wallet:       address.Hex(),
+		TosAccepted:  true,
+		Time:         at.UTC().Format(timeLayout),
+		Action:       action,
+	}
+}
+
+// registrationHash returns the EIP-712 struct hash of an AccountRegistration message.
+func registrationHash(registration user.AccountRegistration, address common.Address) []byte {
+	return crypto.Keccak256(
+		crypto.Keccak256(accountRegistrationType),
+		encodeInt8(registration.AccountIndex),
+		common.LeftPadBytes(address.Bytes(), 32),
+		encodeBool(registration.TosAccepted),
+		crypto.Keccak256([]byte(registration.Time)),
+		crypto.Keccak256([]byte(registration.Action)),
+	)
+}
+
+// signL1Address signs the binding of the Stark public key to the L1 address with the Stark key.
+func signL1Address(address common.Address, keyPair StarkKeyPair) (user.SettlementSignature, error) {
+	msgHash, err := starknet.HashL1Address(new(big.Int).SetBytes(address.Bytes()), keyPair.Public)
+	if err != nil {
+		return user.SettlementSignature{}, err
+	}
+	signer, err := starknet.NewPrivateKeySigner(keyPair.Private)
+	if err != nil {
+		return user.SettlementSignature{}, err
+	}
+	r, s, err := signer.Sign(msgHash)
+	if err != nil {
+		return user.SettlementSignature{}, fmt.Errorf("failed to sign l2 key: %w", err)
+	}
+	return user.SettlementSignature{
+		R: fmt.Sprintf("0x%x", r),
+		S: fmt.Sprintf("0x%x", s),
+	}, nil
+}
+
+// signTypedData signs an EIP-712 message whose domain has only a name and returns the 0x-prefixed
+// r || s || v signature, with v as 27 or 28.
+func signTypedData(l1Key *ecdsa.PrivateKey, signingDomain string, structHash []byte) (string, error) {
+	domainSeparator := crypto.Keccak256(
+		crypto.Keccak256(eip712DomainType),
+		crypto.Keccak256([]byte(signingDomain)),
+	)
+	digest := crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+	return signDigest(l1Key, digest)
+}
+
+// signPersonalMessage signs message as an EIP-191 personal message (eth_sign) and returns the
+// 0x-prefixed r || s || v signature.
+func signPersonalMessage(l1Key *ecdsa.PrivateKey, message string) (string, error) {
+	prefixed := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
+	return signDigest(l1Key, crypto.Keccak256([]byte(prefixed)))
+}
+
+func signDigest(l1Key *ecdsa.PrivateKey, digest []byte) (string, error) {
+	signature, err := crypto.Sign(digest, l1Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign with l1 key: %w", err)
+	}
+	signature[64] += 27
+	return fmt.Sprintf("0x%x", signature), nil
+}
+
+// encodeInt8 ABI-encodes a non-negative int8 as a 32 byte word.
+func encodeInt8(value int) []byte {
+	return common.LeftPadBytes([]byte{byte(value)}, 32)
+}
+
+func encodeBool(value bool) []byte {
+	if value {
+		return common.LeftPadBytes([]byte{1}, 32)
+	}
+	return make([]byte, 32)
+}
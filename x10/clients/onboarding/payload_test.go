@@ -0,0 +1,108 @@
+package onboarding
+
+import (
+	"crypto/ecdsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Vectors from the Python SDK's test_l2_key_derivation.py and test_onboarding_payload.py
+const (
+	testL1Key         = "50c8e358cc974aaaa6e460641e53f78bdc550fd372984aa78ef8fd27c751e6f4"
+	testSigningDomain = "x10.exchange"
+	testWallet        = "0x2c12f074766f5eF9c5300ca8C85d06fBa605C59f"
+	testL2PrivateKey  = "0x7dbb2c8651cc40e1d0d60b45eb52039f317a8aa82798bda52eee272136c0c44"
+	testL2PublicKey   = "0x78298687996aff29a0bbcb994e1305db082d084f85ec38bb78c41e6787740ec"
+)
+
+func testL1PrivateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.HexToECDSA(testL1Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func testKeyPair(t *testing.T) StarkKeyPair {
+	t.Helper()
+	keyPair, err := DeriveL2KeyPair(testL1PrivateKey(t), 0, testSigningDomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyPair
+}
+
+func TestDeriveL2KeyPairMatchesPythonSDK(t *testing.T) {
+	keyPair := testKeyPair(t)
+	if got := "0x" + keyPair.Private.Text(16); got != testL2PrivateKey {
+		t.Errorf("private key = %s, want %s", got, testL2PrivateKey)
+	}
+	if got := keyPair.PublicHex(); got != testL2PublicKey {
+		t.Errorf("public key = %s, want %s", got, testL2PublicKey)
+	}
+	if strings.Contains(keyPair.String(), keyPair.Private.Text(16)) {
+		t.Error("String() leaks the private key")
+	}
+}
+
+func TestDeriveL2KeyPairDependsOnAccountIndex(t *testing.T) {
+	other, err := DeriveL2KeyPair(testL1PrivateKey(t), 1, testSigningDomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.PublicHex() == testL2PublicKey {
+		t.Error("account 1 derived the same key as account 0")
+	}
+}
+
+func TestNewOnboardingRequestMatchesPythonSDK(t *testing.T) {
+	at := time.Date(2024, 7, 30, 16, 1, 2, 0, time.UTC)
+	req, err := NewOnboardingRequest(testL1PrivateKey(t), testSigningDomain, testKeyPair(t), at, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		name, got, want string
+	}{
+		{"l1Signature", req.L1Signature, "0x4b093c2a0206dfa8bc2d09832947a4a567d80a4bfcec14c9874ac2aefcdcf60526c4973007696f26395e75af2383a89fbabe76c5a7a787b5a765f92a4067c58b1c"},
+		{"l2Key", req.L2Key, testL2PublicKey},
+		{"l2Signature.r", req.L2Signature.R, "0x70881694c59c7212b1a47fbbc07df4d32678f0326f778861ec3a2a5dbc09157"},
+		{"l2Signature.s", req.L2Signature.S, "0x558805193faa5d780719cba5f699ae1c888eec1fee23da4215fdd94a744d2cb"},
+		{"accountCreation.wallet", req.AccountCreation.Wallet, testWallet},
+		{"accountCreation.time", req.AccountCreation.Time, "2024-07-30T16:01:02Z"},
+		{"accountCreation.action", req.AccountCreation.Action, ActionRegister},
+	}
+	for _, c := range checks {
+		if !strings.EqualFold(c.got, c.want) {
+			t.Errorf("%s = %s, want %s", c.name, c.got, c.want)
+		}
+	}
+	if req.AccountCreation.AccountIndex != 0 || !req.AccountCreation.TosAccepted {
+		t.Errorf("accountCreation = %+v, want index 0 with tosAccepted", req.AccountCreation)
+	}
+}
+
+func TestNewSubAccountOnboardingRequest(t *testing.T) {
+	l1Key := testL1PrivateKey(t)
+	keyPair, err := DeriveL2KeyPair(l1Key, 3, testSigningDomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 7, 30, 16, 1, 2, 0, time.UTC)
+	req, err := NewSubAccountOnboardingRequest(crypto.PubkeyToAddress(l1Key.PublicKey), 3, keyPair, "bot", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.AccountCreation.Action != ActionCreateSubAccount || req.AccountCreation.AccountIndex != 3 || req.Description != "bot" {
+		t.Errorf("got %+v", req)
+	}
+	if req.L2Key != keyPair.PublicHex() || req.L2Signature.R == "" || req.L2Signature.S == "" {
+		t.Errorf("l2 key or signature missing: %+v", req)
+	}
+}
@@ -0,0 +1,114 @@
+package clients
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+)
+
+// DefaultUserAgent is sent with every REST request and stream connection unless WithUserAgent is used.
+const DefaultUserAgent = "X10GoSDK/1.0"
+
+// DefaultTimeout is the REST request timeout unless WithTimeout or WithHTTPClient is used.
+const DefaultTimeout = 30 * time.Second
+
+// Options holds the settings collected from Option values. Fields left at their zero value
+// fall back to the Config and package defaults.
+type Options struct {
+	HTTPClient *http.Client
+	Timeout    time.Duration
+	UserAgent  string
+	BaseURL    string
+	StreamURL  string
+	Logger     *slog.Logger
+	Account    *starknet.StarknetPerpetualAccount
+	Limiter    *TokenBucket
+}
+
+// Option configures PublicClient and TradingClient constructors.
+type Option func(*Options)
+
+// WithHTTPClient uses client for REST requests, e.g. to set a proxy, custom TLS or a test transport.
+// The proxy and TLS settings of an *http.Transport are applied to stream connections as well.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) { o.HTTPClient = client }
+}
+
+// WithTimeout sets the REST request timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Options) { o.Timeout = timeout }
+}
+
+// WithUserAgent sets the User-Agent header.
+func WithUserAgent(userAgent string) Option {
+	return func(o *Options) { o.UserAgent = userAgent }
+}
+
+// WithBaseURL overrides Config.APIBaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *Options) { o.BaseURL = baseURL }
+}
+
+// WithStreamURL overrides Config.StreamURL.
+func WithStreamURL(streamURL string) Option {
+	return func(o *Options) { o.StreamURL = streamURL }
+}
+
+// WithLogger overrides Config.Logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *Options) { o.Logger = logger }
+}
+
+// WithRateLimiter sets the client-side rate limiter for REST requests. Pass the same limiter to every
+// client in a process to keep them under the exchange's per-IP limit together. Without it each client
+// gets its own limiter tuned to DefaultRequestsPerMinute.
+func WithRateLimiter(limiter *TokenBucket) Option {
+	return func(o *Options) { o.Limiter = limiter }
+}
+
+// WithAccount sets the Starknet account a TradingClient trades for, instead of loading it from
+// environment variables. It lets one process run clients for several sub-accounts. PublicClient ignores it.
+func WithAccount(account *starknet.StarknetPerpetualAccount) Option {
+	return func(o *Options) { o.Account = account }
+}
+
+// ResolveOptions applies opts on top of the defaults taken from cfg.
+func ResolveOptions(cfg *x10.Config, opts ...Option) Options {
+	o := Options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	if o.BaseURL == "" {
+		o.BaseURL = cfg.APIBaseURL
+	}
+	if o.StreamURL == "" {
+		o.StreamURL = cfg.StreamURL
+	}
+	if o.UserAgent == "" {
+		o.UserAgent = DefaultUserAgent
+	}
+	if o.Logger == nil {
+		o.Logger = cfg.SlogLogger()
+	}
+	if o.Limiter == nil {
+		o.Limiter = NewTokenBucket(DefaultRequestsPerMinute, DefaultBurst)
+	}
+
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	} else {
+		// Copy so that WithTimeout never modifies the caller's client
+		client := *o.HTTPClient
+		o.HTTPClient = &client
+	}
+	if o.Timeout > 0 {
+		o.HTTPClient.Timeout = o.Timeout
+	}
+	return o
+}
@@ -0,0 +1,141 @@
+package clients
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+)
+
+func TestResolveOptions(t *testing.T) {
+	cfg := x10.Testnet()
+	cfgLogger := slog.New(slog.DiscardHandler)
+	logger := slog.New(slog.DiscardHandler)
+	account := &starknet.StarknetPerpetualAccount{Vault: 10002}
+	limiter := NewTokenBucket(60, 1)
+	transport := &http.Transport{}
+
+	tests := []struct {
+		name  string
+		cfg   *x10.Config
+		opts  []Option
+		check func(t *testing.T, o Options)
+	}{
+		{
+			name: "defaults from config",
+			cfg:  cfg,
+			check: func(t *testing.T, o Options) {
+				if o.BaseURL != cfg.APIBaseURL || o.StreamURL != cfg.StreamURL {
+					t.Errorf("urls = %s, %s; want the config's", o.BaseURL, o.StreamURL)
+				}
+				if o.UserAgent != DefaultUserAgent {
+					t.Errorf("UserAgent = %q, want %q", o.UserAgent, DefaultUserAgent)
+				}
+				if o.HTTPClient == nil || o.HTTPClient.Timeout != DefaultTimeout {
+					t.Errorf("HTTPClient = %+v, want a client with the default timeout", o.HTTPClient)
+				}
+				if o.Logger == nil || o.Limiter == nil || o.Account != nil {
+					t.Errorf("logger %v, limiter %v, account %v; want a logger and a limiter only", o.Logger, o.Limiter, o.Account)
+				}
+			},
+		},
+		{
+			name: "config logger",
+			cfg:  &x10.Config{Logger: cfgLogger},
+			check: func(t *testing.T, o Options) {
+				if o.Logger != cfgLogger {
+					t.Error("Logger is not the config's logger")
+				}
+			},
+		},
+		{
+			name: "overrides",
+			cfg:  cfg,
+			opts: []Option{
+				WithBaseURL("http://localhost:1"),
+				WithStreamURL("ws://localhost:2"),
+				WithUserAgent("bot/2.0"),
+				WithTimeout(time.Second),
+				WithLogger(logger),
+				WithAccount(account),
+				WithRateLimiter(limiter),
+			},
+			check: func(t *testing.T, o Options) {
+				if o.BaseURL != "http://localhost:1" || o.StreamURL != "ws://localhost:2" || o.UserAgent != "bot/2.0" {
+					t.Errorf("got %s, %s, %q", o.BaseURL, o.StreamURL, o.UserAgent)
+				}
+				if o.HTTPClient.Timeout != time.Second {
+					t.Errorf("Timeout = %s, want 1s", o.HTTPClient.Timeout)
+				}
+				if o.Logger != logger || o.Account != account || o.Limiter != limiter {
+					t.Error("logger, account or limiter option was not applied")
+				}
+			},
+		},
+		{
+			name: "later option wins, nil ignored",
+			cfg:  cfg,
+			opts: []Option{WithUserAgent("first"), nil, WithUserAgent("second")},
+			check: func(t *testing.T, o Options) {
+				if o.UserAgent != "second" {
+					t.Errorf("UserAgent = %q, want second", o.UserAgent)
+				}
+			},
+		},
+		{
+			name: "custom http client keeps its transport and timeout",
+			cfg:  cfg,
+			opts: []Option{WithHTTPClient(&http.Client{Transport: transport, Timeout: 5 * time.Second})},
+			check: func(t *testing.T, o Options) {
+				if o.HTTPClient.Transport != transport || o.HTTPClient.Timeout != 5*time.Second {
+					t.Errorf("HTTPClient = %+v, want the given transport and timeout", o.HTTPClient)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, ResolveOptions(tt.cfg, tt.opts...))
+		})
+	}
+}
+
+func TestResolveOptionsCopiesHTTPClient(t *testing.T) {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	o := ResolveOptions(x10.Testnet(), WithHTTPClient(client), WithTimeout(time.Second))
+	if o.HTTPClient == client {
+		t.Fatal("ResolveOptions returned the caller's client")
+	}
+	if o.HTTPClient.Timeout != time.Second {
+		t.Errorf("Timeout = %s, want 1s", o.HTTPClient.Timeout)
+	}
+	if client.Timeout != 5*time.Second {
+		t.Errorf("caller's client timeout changed to %s", client.Timeout)
+	}
+}
+
+func TestHTTPClientUsesOptions(t *testing.T) {
+	var gotAgent, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAgent = r.Header.Get("User-Agent")
+		gotKey = r.Header.Get("X-Api-Key")
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewHTTPClientWithAPIKey(x10.Testnet(), "sub-account-key", WithBaseURL(srv.URL), WithUserAgent("bot/2.0"))
+	c.SetRateLimiter(nil)
+	if err := c.Get(context.Background(), "/info/markets", nil); err != nil {
+		t.Fatal(err)
+	}
+	if gotAgent != "bot/2.0" || gotKey != "sub-account-key" {
+		t.Errorf("sent User-Agent %q, X-Api-Key %q; want bot/2.0, sub-account-key", gotAgent, gotKey)
+	}
+}
@@ -0,0 +1,68 @@
+package clients
+
+import (
+	"context"
+	"iter"
+)
+
+// PageOptions controls the iterators that follow pagination cursors (e.g. TradingClient.AllTrades).
+type PageOptions struct {
+	PageSize int // items requested per page; zero uses the endpoint's default
+	MaxItems int // stop after this many items; zero iterates until the history is exhausted
+}
+
+// PageFetcher loads the page after cursor (nil for the first page) with at most limit items (nil for
+// the endpoint's default). It returns the page's items and the cursor of the next page.
+type PageFetcher[T any] func(ctx context.Context, cursor *int64, limit *int) ([]T, int64, error)
+
+// Paginate returns an iterator over all items of a cursor-paginated endpoint. Pages are fetched lazily
+// as the loop advances, until a page comes back empty, the cursor stops moving, MaxItems is
+// reached or ctx is cancelled. A failed request or a cancelled ctx is yielded as the final error.
+func Paginate[T any](ctx context.Context, opts PageOptions, fetch PageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var cursor *int64
+		count := 0
+
+		for {
+			var limit *int
+			if opts.PageSize > 0 {
+				size := opts.PageSize
+				if opts.MaxItems > 0 && opts.MaxItems-count < size {
+					size = opts.MaxItems - count
+				}
+				limit = &size
+			}
+
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, next, err := fetch(ctx, cursor, limit)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if opts.MaxItems > 0 && count >= opts.MaxItems {
+					return
+				}
+			}
+
+			// A short page is not the end: the server may cap the page size below the limit
+			if len(items) == 0 || next == 0 || (cursor != nil && next == *cursor) {
+				return
+			}
+			cursor = &next
+		}
+	}
+}
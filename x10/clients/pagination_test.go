@@ -0,0 +1,122 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakePages serves pages in order, following the cursor each page returns.
+type fakePages struct {
+	pages   [][]int
+	cursors []int64 // cursor returned with each page
+	calls   int
+}
+
+func (f *fakePages) fetch(ctx context.Context, cursor *int64, limit *int) ([]int, int64, error) {
+	if f.calls >= len(f.pages) {
+		return nil, 0, errors.New("fetched past the last page")
+	}
+	if f.calls > 0 && (cursor == nil || *cursor != f.cursors[f.calls-1]) {
+		return nil, 0, errors.New("unexpected cursor")
+	}
+	page, next := f.pages[f.calls], f.cursors[f.calls]
+	f.calls++
+	return page, next, nil
+}
+
+func collect(t *testing.T, opts PageOptions, pages *fakePages) []int {
+	t.Helper()
+	var got []int
+	for item, err := range Paginate(context.Background(), opts, pages.fetch) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, item)
+	}
+	return got
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      PageOptions
+		pages     [][]int
+		cursors   []int64
+		want      int
+		wantCalls int
+	}{
+		{
+			name:      "short pages with a cursor continue",
+			opts:      PageOptions{PageSize: 3},
+			pages:     [][]int{{1, 2}, {3}, {4, 5, 6}, {}},
+			cursors:   []int64{10, 20, 30, 40},
+			want:      6,
+			wantCalls: 4,
+		},
+		{
+			name:      "zero cursor ends",
+			opts:      PageOptions{PageSize: 3},
+			pages:     [][]int{{1, 2, 3}, {4}},
+			cursors:   []int64{10, 0},
+			want:      4,
+			wantCalls: 2,
+		},
+		{
+			name:      "unchanged cursor ends",
+			pages:     [][]int{{1}, {2}},
+			cursors:   []int64{10, 10},
+			want:      2,
+			wantCalls: 2,
+		},
+		{
+			name:      "max items",
+			opts:      PageOptions{PageSize: 2, MaxItems: 3},
+			pages:     [][]int{{1, 2}, {3}},
+			cursors:   []int64{10, 20},
+			want:      3,
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := &fakePages{pages: tt.pages, cursors: tt.cursors}
+			got := collect(t, tt.opts, pages)
+			if len(got) != tt.want {
+				t.Errorf("got %d items, want %d", len(got), tt.want)
+			}
+			for i, item := range got {
+				if item != i+1 {
+					t.Fatalf("items = %v, want 1..%d in order", got, tt.want)
+				}
+			}
+			if pages.calls != tt.wantCalls {
+				t.Errorf("fetched %d pages, want %d", pages.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPaginateYieldsFetchError(t *testing.T) {
+	failure := errors.New("boom")
+	fetch := func(ctx context.Context, cursor *int64, limit *int) ([]int, int64, error) {
+		if cursor == nil {
+			return []int{1}, 10, nil
+		}
+		return nil, 0, failure
+	}
+
+	var items int
+	var last error
+	for _, err := range Paginate(context.Background(), PageOptions{}, fetch) {
+		if err != nil {
+			last = err
+			continue
+		}
+		items++
+	}
+	if items != 1 || !errors.Is(last, failure) {
+		t.Errorf("got %d items and err %v, want 1 item then %v", items, last, failure)
+	}
+}
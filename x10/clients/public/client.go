@@ -1,8 +1,11 @@
 package public
 
 import (
+	"log/slog"
+
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients/stream"
 )
 
 // PublicClient provides access to public market data endpoints that don't require authentication.
@@ -11,16 +14,47 @@ import (
 type PublicClient struct {
 	httpClient *clients.HTTPClient
 	streaming  bool
+	stream     *stream.StreamClient
 }
 
-func NewPublicClient(cfg *x10.Config, enableStreaming bool) *PublicClient {
-	return &PublicClient{
-		httpClient: clients.NewHTTPClient(cfg),
+// NewPublicClient creates a client for the public endpoints of cfg, customised by opts
+// (e.g. clients.WithHTTPClient, clients.WithTimeout, clients.WithBaseURL).
+func NewPublicClient(cfg *x10.Config, enableStreaming bool, opts ...clients.Option) *PublicClient {
+	c := &PublicClient{
+		httpClient: clients.NewHTTPClient(cfg, opts...),
 		streaming:  enableStreaming,
 	}
+	if enableStreaming {
+		c.stream = stream.NewStreamClient(cfg, opts...)
+	}
+	return c
 }
 
 // StreamingEnabled returns whether streaming features are enabled on this client.
 func (c *PublicClient) StreamingEnabled() bool {
 	return c.streaming
 }
+
+// Stream returns the WebSocket stream client for public order book, trades, funding and candle streams.
+// Returns nil when the client was created with streaming disabled.
+func (c *PublicClient) Stream() *stream.StreamClient {
+	return c.stream
+}
+
+// SetRetryPolicy sets how failed REST requests are retried. See clients.RetryPolicy.
+func (c *PublicClient) SetRetryPolicy(policy clients.RetryPolicy) {
+	c.httpClient.SetRetryPolicy(policy)
+}
+
+// SetRateLimiter sets the client-side rate limiter for REST requests; nil disables it.
+func (c *PublicClient) SetRateLimiter(limiter *clients.TokenBucket) {
+	c.httpClient.SetRateLimiter(limiter)
+}
+
+// SetLogger sets the logger for REST and stream diagnostics; nil discards them.
+func (c *PublicClient) SetLogger(logger *slog.Logger) {
+	c.httpClient.SetLogger(logger)
+	if c.stream != nil {
+		c.stream.SetLogger(logger)
+	}
+}
@@ -0,0 +1,28 @@
+package public
+
+import (
+	"context"
+	"iter"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+)
+
+// FundingRatesFilter selects the funding rates returned by AllFundingRates.
+type FundingRatesFilter struct {
+	Market    string
+	StartTime int64 // epoch milliseconds
+	EndTime   int64 // epoch milliseconds
+}
+
+// AllFundingRates iterates over the funding rates history of a market, following the pagination
+// cursor (see clients.Paginate).
+func (c *PublicClient) AllFundingRates(ctx context.Context, filter FundingRatesFilter, page clients.PageOptions) iter.Seq2[info.FundingRate, error] {
+	return clients.Paginate(ctx, page, func(ctx context.Context, cursor *int64, limit *int) ([]info.FundingRate, int64, error) {
+		response, err := c.GetFundingRates(ctx, filter.Market, filter.StartTime, filter.EndTime, cursor, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Data, response.Pagination.Cursor, nil
+	})
+}
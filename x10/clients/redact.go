@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are replaced before request headers are logged.
+var sensitiveHeaders = map[string]bool{
+	"X-Api-Key":     true,
+	"Authorization": true,
+	"L1_signature":  true,
+}
+
+// sensitiveFields are replaced wherever they appear in a logged JSON body (compared case-insensitively).
+var sensitiveFields = map[string]bool{
+	"signature":   true,
+	"l1signature": true,
+	"l2signature": true,
+	"privatekey":  true,
+	"private_key": true,
+	"apikey":      true,
+	"api_key":     true,
+	"key":         true,
+	"secret":      true,
+}
+
+// redactHeaders returns a copy of h suitable for logging, with credentials replaced.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			out[name] = redacted
+			continue
+		}
+		out[name] = strings.Join(values, ",")
+	}
+	return out
+}
+
+// redactBody returns a JSON body suitable for logging, with signatures and key material replaced.
+// Bodies that are not JSON are not logged, only their size.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("[non-JSON body, %d bytes]", len(body))
+	}
+	out, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return "[unloggable body]"
+	}
+	return string(out)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, inner := range v {
+			if sensitiveFields[strings.ToLower(field)] {
+				v[field] = redacted
+				continue
+			}
+			v[field] = redactValue(inner)
+		}
+		return v
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = redactValue(inner)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,142 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   map[string]string
+	}{
+		{
+			name:   "api key",
+			header: http.Header{"X-Api-Key": {"secret-key"}, "Accept": {"application/json"}},
+			want:   map[string]string{"X-Api-Key": redacted, "Accept": "application/json"},
+		},
+		{
+			name:   "authorization",
+			header: http.Header{"Authorization": {"Bearer token"}},
+			want:   map[string]string{"Authorization": redacted},
+		},
+		{
+			name:   "header sent exactly as given",
+			header: http.Header{"L1_SIGNATURE": {"0xabc"}, "L1_MESSAGE_TIME": {"2024-01-01T00:00:00Z"}},
+			want:   map[string]string{"L1_SIGNATURE": redacted, "L1_MESSAGE_TIME": "2024-01-01T00:00:00Z"},
+		},
+		{
+			name:   "lower-case name",
+			header: http.Header{"x-api-key": {"secret-key"}},
+			want:   map[string]string{"x-api-key": redacted},
+		},
+		{
+			name:   "multiple values",
+			header: http.Header{"Accept": {"application/json", "text/plain"}},
+			want:   map[string]string{"Accept": "application/json,text/plain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactHeaders(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("redactHeaders = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeadersKeepsRequestHeaders(t *testing.T) {
+	header := http.Header{"X-Api-Key": {"secret-key"}}
+	redactHeaders(header)
+	if got := header.Get("X-Api-Key"); got != "secret-key" {
+		t.Errorf("request header changed to %q", got)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string // JSON compared semantically, other output exactly
+	}{
+		{name: "empty", body: "", want: ""},
+		{name: "not JSON", body: "key=secret", want: "[non-JSON body, 10 bytes]"},
+		{
+			name: "order settlement",
+			body: `{"id":"1","settlement":{"signature":{"r":"0x1","s":"0x2"},"starkKey":"0x3"}}`,
+			want: `{"id":"1","settlement":{"signature":"[REDACTED]","starkKey":"0x3"}}`,
+		},
+		{
+			name: "field names compared case-insensitively",
+			body: `{"l1Signature":"0x1","L2Signature":"0x2","apiKey":"k","API_KEY":"k","privateKey":"0x4","Secret":"s"}`,
+			want: `{"l1Signature":"[REDACTED]","L2Signature":"[REDACTED]","apiKey":"[REDACTED]","API_KEY":"[REDACTED]","privateKey":"[REDACTED]","Secret":"[REDACTED]"}`,
+		},
+		{
+			name: "nested in arrays",
+			body: `{"data":[{"key":"k1","name":"a"},{"key":"k2","name":"b"}]}`,
+			want: `{"data":[{"key":"[REDACTED]","name":"a"},{"key":"[REDACTED]","name":"b"}]}`,
+		},
+		{name: "top-level array", body: `[{"secret":"s"},1,"x"]`, want: `[{"secret":"[REDACTED]"},1,"x"]`},
+		{name: "nothing sensitive", body: `{"market":"BTC-USD","qty":"0.1"}`, want: `{"market":"BTC-USD","qty":"0.1"}`},
+		{name: "scalar", body: `"key"`, want: `"key"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactBody([]byte(tt.body))
+			if !json.Valid([]byte(tt.want)) {
+				if got != tt.want {
+					t.Errorf("redactBody = %q, want %q", got, tt.want)
+				}
+				return
+			}
+
+			var gotValue, wantValue interface{}
+			if err := json.Unmarshal([]byte(got), &gotValue); err != nil {
+				t.Fatalf("redactBody returned invalid JSON %q: %v", got, err)
+			}
+			json.Unmarshal([]byte(tt.want), &wantValue)
+			if !reflect.DeepEqual(gotValue, wantValue) {
+				t.Errorf("redactBody = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDebugLogRedactsRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"OK","data":{"key":"response-secret"}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	var logs bytes.Buffer
+	c := NewHTTPClientWithAPIKey(x10.Testnet(), "api-key-secret", WithBaseURL(srv.URL),
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	c.SetRateLimiter(nil)
+
+	payload := map[string]interface{}{"market": "BTC-USD", "settlement": map[string]string{"signature": "signature-secret"}}
+	if err := c.Post(context.Background(), "/user/order", payload, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"api-key-secret", "signature-secret", "response-secret"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("debug log contains %q:\n%s", secret, logs.String())
+		}
+	}
+	if !strings.Contains(logs.String(), "BTC-USD") {
+		t.Errorf("debug log lacks the request body:\n%s", logs.String())
+	}
+}
@@ -0,0 +1,130 @@
+package clients
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how HTTPClient retries failed requests.
+//
+// Rate-limited requests (HTTP 429) were not processed by the exchange and are retried for every
+// method after the delay requested by Retry-After. When that delay exceeds MaxBackoff, the
+// *models.RateLimitError is returned instead. GET requests are also retried on 5xx responses and network errors.
+// Other requests are only retried on those errors when sent with PostIdempotent.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts including the first; 1 disables retries
+	InitialBackoff time.Duration // delay before the first retry
+	MaxBackoff     time.Duration // upper bound for the delay between attempts, and the longest Retry-After waited for
+	Multiplier     float64       // backoff growth factor per failed attempt
+	Jitter         float64       // random +/- fraction applied to each delay, e.g. 0.2 for 20%
+}
+
+// DefaultRetryPolicy makes up to 3 attempts with backoff from 250ms up to 5s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetry makes a single attempt per request.
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// backoff returns the jittered delay before retry number attempt (starting at 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	return Backoff(attempt, p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter)
+}
+
+// Backoff returns the delay before attempt number attempt (starting at 1): initial grown by multiplier
+// per previous attempt, capped at max (0 for no cap) and with a random +/- jitter fraction applied.
+// It is shared by RetryPolicy and the stream client's ReconnectPolicy.
+func Backoff(attempt int, initial, max time.Duration, multiplier, jitter float64) time.Duration {
+	delay := float64(initial)
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if max > 0 && delay >= float64(max) {
+			delay = float64(max)
+			break
+		}
+	}
+	if jitter > 0 {
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
+}
+
+// TokenBucket is a client-side rate limiter. Each request takes one token; tokens refill
+// continuously at the configured rate up to burst.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a limiter allowing requestsPerMinute on average with bursts of up to burst requests.
+func NewTokenBucket(requestsPerMinute int, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   float64(requestsPerMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Exchange REST limit per IP address, used for the limiter of clients created without WithRateLimiter.
+const (
+	DefaultRequestsPerMinute = 1000
+	DefaultBurst             = 20
+)
+
+// Wait blocks until a token is available or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		if b.rate <= 0 {
+			b.mu.Unlock()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempt    int
+		initial    time.Duration
+		max        time.Duration
+		multiplier float64
+		want       time.Duration
+	}{
+		{name: "first attempt", attempt: 1, initial: 250 * time.Millisecond, max: 5 * time.Second, multiplier: 2, want: 250 * time.Millisecond},
+		{name: "grows", attempt: 3, initial: 250 * time.Millisecond, max: 5 * time.Second, multiplier: 2, want: time.Second},
+		{name: "capped", attempt: 10, initial: 250 * time.Millisecond, max: 5 * time.Second, multiplier: 2, want: 5 * time.Second},
+		{name: "no cap", attempt: 4, initial: time.Second, multiplier: 3, want: 27 * time.Second},
+		{name: "multiplier below one is constant", attempt: 5, initial: time.Second, max: time.Minute, multiplier: 0.5, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Backoff(tt.attempt, tt.initial, tt.max, tt.multiplier, 0); got != tt.want {
+				t.Errorf("Backoff = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		got := Backoff(2, time.Second, 0, 2, 0.2)
+		if got < 1600*time.Millisecond || got > 2400*time.Millisecond {
+			t.Fatalf("Backoff = %s, want within 20%% of 2s", got)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+
+	if got := NoRetry().MaxAttempts; got != 1 {
+		t.Errorf("NoRetry().MaxAttempts = %d, want 1", got)
+	}
+}
+
+func TestTokenBucketBurst(t *testing.T) {
+	bucket := NewTokenBucket(60, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := bucket.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("burst of 3 took %s, want no wait", elapsed)
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	// 1200 per minute refills a token every 50ms
+	bucket := NewTokenBucket(1200, 1)
+	ctx := context.Background()
+
+	if err := bucket.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := bucket.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond || elapsed > time.Second {
+		t.Errorf("second token took %s, want about 50ms", elapsed)
+	}
+}
+
+func TestTokenBucketContextCancel(t *testing.T) {
+	bucket := NewTokenBucket(1, 1)
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bucket.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestTokenBucketZeroRate(t *testing.T) {
+	bucket := NewTokenBucket(0, 0)
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("burst is at least one token: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bucket.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,18 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+)
+
+// SubscribeToAccountUpdates streams private updates for the sub-account owning apiKey:
+// order status changes (ORDER), fills (TRADE), positions (POSITION) and balance (BALANCE).
+// Message types the SDK does not know about are still delivered with an empty payload.
+func (c *StreamClient) SubscribeToAccountUpdates(ctx context.Context, apiKey string) (*Subscription[user.AccountUpdate], error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("api key is required for account stream")
+	}
+	return subscribe[user.AccountUpdate](ctx, c, "/account", apiKey)
+}
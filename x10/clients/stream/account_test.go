@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSubscribeToAccountUpdates(t *testing.T) {
+	client, _ := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/account" || r.Header.Get("X-Api-Key") != "test-api-key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		conn := upgrade(t, w, r)
+		if conn == nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ORDER","seq":1,"data":{"orders":[{"id":42,"market":"BTC-USD","status":"NEW"}]}}`))
+		drain(conn)
+	})
+
+	if _, err := client.SubscribeToAccountUpdates(context.Background(), ""); err == nil {
+		t.Error("expected an error without an api key")
+	}
+
+	sub, err := client.SubscribeToAccountUpdates(context.Background(), "test-api-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	msg := next(t, sub)
+	if msg.Type != MessageTypeOrder || len(msg.Data.Orders) != 1 || msg.Data.Orders[0].ID != 42 {
+		t.Errorf("got %+v, want one ORDER update for order 42", msg)
+	}
+}
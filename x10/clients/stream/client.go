@@ -0,0 +1,149 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+)
+
+// StreamClient opens WebSocket subscriptions against Config.StreamURL.
+// This is the Go equivalent of Python's PerpetualStreamClient: every subscription
+// uses its own connection and delivers decoded messages on a typed channel.
+type StreamClient struct {
+	streamURL string
+	userAgent string
+	dialer    *websocket.Dialer
+	logger    *slog.Logger
+
+	mu     sync.Mutex
+	policy ReconnectPolicy
+	active map[io.Closer]struct{}
+}
+
+// NewStreamClient creates a stream client for cfg.StreamURL. Of the options, WithStreamURL,
+// WithUserAgent, WithLogger and the proxy and TLS settings of WithHTTPClient apply to streams.
+func NewStreamClient(cfg *x10.Config, opts ...clients.Option) *StreamClient {
+	o := clients.ResolveOptions(cfg, opts...)
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 30 * time.Second,
+	}
+	if transport, ok := o.HTTPClient.Transport.(*http.Transport); ok {
+		dialer.Proxy = transport.Proxy
+		dialer.TLSClientConfig = transport.TLSClientConfig
+	}
+
+	return &StreamClient{
+		streamURL: strings.TrimRight(o.StreamURL, "/"),
+		userAgent: o.UserAgent,
+		dialer:    dialer,
+		logger:    o.Logger,
+		policy:    DefaultReconnectPolicy(),
+		active:    make(map[io.Closer]struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for connection diagnostics. A nil logger discards them.
+func (c *StreamClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger = logger
+}
+
+func (c *StreamClient) log() *slog.Logger {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.logger
+}
+
+// SetReconnectPolicy changes the reconnect and heartbeat behaviour for subscriptions opened afterwards.
+func (c *StreamClient) SetReconnectPolicy(policy ReconnectPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.policy = policy
+}
+
+func (c *StreamClient) reconnectPolicy() ReconnectPolicy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.policy
+}
+
+// ActiveSubscriptions returns the number of subscriptions that are currently running.
+func (c *StreamClient) ActiveSubscriptions() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.active)
+}
+
+// Close terminates every active subscription opened through this client.
+func (c *StreamClient) Close() error {
+	c.mu.Lock()
+	subs := make([]io.Closer, 0, len(c.active))
+	for sub := range c.active {
+		subs = append(subs, sub)
+	}
+	c.mu.Unlock()
+
+	var firstErr error
+	for _, sub := range subs {
+		if err := sub.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c *StreamClient) track(sub io.Closer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active[sub] = struct{}{}
+}
+
+func (c *StreamClient) untrack(sub io.Closer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.active, sub)
+}
+
+// connect dials the stream endpoint at path, authenticating with apiKey when it is not empty.
+func (c *StreamClient) connect(ctx context.Context, path string, apiKey string) (*websocket.Conn, error) {
+	streamURL := c.streamURL + path
+
+	headers := http.Header{}
+	headers.Set("User-Agent", c.userAgent)
+	if apiKey != "" {
+		headers.Set("X-Api-Key", apiKey)
+	}
+
+	conn, resp, err := c.dialer.DialContext(ctx, streamURL, headers)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("failed to connect to stream %s: status %d: %w", path, resp.StatusCode, err)
+		}
+		return nil, fmt.Errorf("failed to connect to stream %s: %w", path, err)
+	}
+	return conn, nil
+}
+
+// marketPath builds a stream path with an optional market segment; an empty market subscribes to all markets.
+func marketPath(base, market string) string {
+	if market == "" {
+		return base
+	}
+	return base + "/" + url.PathEscape(market)
+}
@@ -0,0 +1,39 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+)
+
+// SubscribeToOrderBooks streams order book updates for a market (or all markets when market is empty).
+// The first message is a SNAPSHOT, followed by DELTA messages carrying changed price levels.
+func (c *StreamClient) SubscribeToOrderBooks(ctx context.Context, market string) (*Subscription[info.OrderBook], error) {
+	return subscribe[info.OrderBook](ctx, c, marketPath("/orderbooks", market), "")
+}
+
+// SubscribeToPublicTrades streams public trades for a market (or all markets when market is empty).
+func (c *StreamClient) SubscribeToPublicTrades(ctx context.Context, market string) (*Subscription[[]info.Trade], error) {
+	return subscribe[[]info.Trade](ctx, c, marketPath("/publicTrades", market), "")
+}
+
+// SubscribeToFundingRates streams funding rate updates for a market (or all markets when market is empty).
+func (c *StreamClient) SubscribeToFundingRates(ctx context.Context, market string) (*Subscription[info.FundingRate], error) {
+	return subscribe[info.FundingRate](ctx, c, marketPath("/funding", market), "")
+}
+
+// SubscribeToCandles streams candle updates for a market.
+// candleType can be "trades", "mark-prices", or "index-prices"; interval e.g. "PT1M", "PT1H", "P1D".
+func (c *StreamClient) SubscribeToCandles(ctx context.Context, market, candleType, interval string) (*Subscription[[]info.Candle], error) {
+	if market == "" {
+		return nil, fmt.Errorf("market is required for candles stream")
+	}
+
+	q := url.Values{}
+	q.Set("interval", interval)
+	path := fmt.Sprintf("/candles/%s/%s?%s", url.PathEscape(market), candleType, q.Encode())
+
+	return subscribe[[]info.Candle](ctx, c, path, "")
+}
@@ -0,0 +1,37 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+)
+
+// ReconnectPolicy controls how a Subscription keeps its connection alive and recovers from drops.
+type ReconnectPolicy struct {
+	InitialBackoff time.Duration // delay before the first reconnect attempt
+	MaxBackoff     time.Duration // upper bound for the delay between attempts
+	Multiplier     float64       // backoff growth factor per failed attempt
+	Jitter         float64       // random +/- fraction applied to each delay, e.g. 0.2 for 20%
+	MaxAttempts    int           // consecutive failed attempts before giving up; 0 retries forever
+
+	PingInterval time.Duration // how often to ping the server; 0 disables the heartbeat
+	PongTimeout  time.Duration // how long to wait past PingInterval for any traffic before treating the connection as dead
+}
+
+// DefaultReconnectPolicy retries forever with backoff from 500ms up to 30s and pings every 15s.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxAttempts:    0,
+		PingInterval:   15 * time.Second,
+		PongTimeout:    10 * time.Second,
+	}
+}
+
+// backoff returns the jittered delay before reconnect attempt number attempt (starting at 1).
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	return clients.Backoff(attempt, p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter)
+}
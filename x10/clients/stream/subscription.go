@@ -0,0 +1,315 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Message types carried in the stream envelope.
+const (
+	MessageTypeSnapshot   = "SNAPSHOT"
+	MessageTypeDelta      = "DELTA"
+	MessageTypeOrder      = "ORDER"
+	MessageTypeTrade      = "TRADE"
+	MessageTypePosition   = "POSITION"
+	MessageTypeBalance    = "BALANCE"
+	MessageTypeDeposit    = "DEPOSIT"
+	MessageTypeTransfer   = "TRANSFER"
+	MessageTypeWithdrawal = "WITHDRAWAL"
+
+	// MessageTypeGap is emitted by the SDK (never by the exchange) right after a dropped connection
+	// has been re-established. Messages may have been missed in between, so consumers should resync
+	// their state via the REST getters (GetOrderBook, GetOpenOrders, GetPositions, ...).
+	MessageTypeGap = "GAP"
+)
+
+// Message is the envelope every stream message is wrapped in (WrappedStreamResponse in the Python SDK).
+type Message[T any] struct {
+	Type  string `json:"type"`
+	Data  T      `json:"data"`
+	Error string `json:"error,omitempty"`
+	Ts    int64  `json:"ts"`  // epoch milliseconds
+	Seq   int64  `json:"seq"` // per-connection sequence number
+}
+
+// Subscription is a live stream of typed messages. Messages are delivered on C,
+// which is closed once the subscription ends; Err reports why it ended.
+// Dropped connections are re-established according to the client's ReconnectPolicy,
+// after which a MessageTypeGap message is delivered.
+type Subscription[T any] struct {
+	C <-chan Message[T]
+
+	client    *StreamClient
+	path      string
+	apiKey    string
+	policy    ReconnectPolicy
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+	err  error
+}
+
+// decodeError marks failures that reconnecting cannot fix.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("failed to unmarshal stream message: %v", e.err)
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// subscribe opens a connection for path and starts decoding messages into a Subscription.
+// Only the initial connection error is returned; later drops are handled by reconnecting.
+func subscribe[T any](ctx context.Context, c *StreamClient, path string, apiKey string) (*Subscription[T], error) {
+	conn, err := c.connect(ctx, path, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan Message[T], 64)
+	sub := &Subscription[T]{
+		C:      ch,
+		client: c,
+		path:   path,
+		apiKey: apiKey,
+		policy: c.reconnectPolicy(),
+		done:   make(chan struct{}),
+		conn:   conn,
+	}
+
+	c.track(sub)
+	go sub.run(ctx, ch)
+	return sub, nil
+}
+
+func (s *Subscription[T]) run(ctx context.Context, ch chan<- Message[T]) {
+	defer close(ch)
+	defer s.client.untrack(s)
+
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+
+	for {
+		err := s.readConn(ctx, conn, ch)
+		conn.Close()
+
+		if s.closed() {
+			return
+		}
+		if ctx.Err() != nil {
+			s.setErr(ctx.Err())
+			return
+		}
+		if _, ok := err.(*decodeError); ok {
+			s.setErr(err)
+			return
+		}
+
+		conn, err = s.reconnect(ctx, err)
+		if err != nil {
+			s.setErr(err)
+			return
+		}
+		if conn == nil {
+			// Closed by the caller while reconnecting
+			return
+		}
+
+		gap := Message[T]{Type: MessageTypeGap, Ts: time.Now().UnixMilli()}
+		if !s.deliver(ctx, ch, gap) {
+			return
+		}
+	}
+}
+
+// readConn reads and delivers messages from conn until it fails, keeping it alive with pings.
+func (s *Subscription[T]) readConn(ctx context.Context, conn *websocket.Conn, ch chan<- Message[T]) error {
+	stopped := make(chan struct{})
+	defer close(stopped)
+
+	// Unblock ReadMessage when the caller cancels the context or closes the subscription.
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-s.done:
+		case <-stopped:
+		}
+	}()
+
+	// Without a heartbeat the read deadline is never moved.
+	extend := func() {}
+	if s.policy.PingInterval > 0 {
+		readTimeout := s.policy.PingInterval + s.policy.PongTimeout
+		extend = func() {
+			_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+		}
+		extend()
+
+		conn.SetPongHandler(func(string) error {
+			extend()
+			return nil
+		})
+		replyPing := conn.PingHandler()
+		conn.SetPingHandler(func(data string) error {
+			extend()
+			return replyPing(data)
+		})
+
+		go func() {
+			ticker := time.NewTicker(s.policy.PingInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					deadline := time.Now().Add(s.policy.PongTimeout)
+					if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+						return
+					}
+				case <-stopped:
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if err := s.handle(ctx, data, ch); err != nil {
+			return err
+		}
+		// Pongs are only processed while reading, so the heartbeat deadline restarts once the
+		// message has been delivered; a slow consumer must not look like a dead connection.
+		extend()
+	}
+}
+
+func (s *Subscription[T]) handle(ctx context.Context, data []byte, ch chan<- Message[T]) error {
+	var msg Message[T]
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return &decodeError{err: err}
+	}
+	if !s.deliver(ctx, ch, msg) {
+		return context.Canceled
+	}
+	return nil
+}
+
+// reconnect re-dials the subscription path with exponential backoff until it succeeds,
+// the policy runs out of attempts, or the subscription is closed.
+func (s *Subscription[T]) reconnect(ctx context.Context, cause error) (*websocket.Conn, error) {
+	logger := s.client.log()
+	logger.Info("stream connection lost, reconnecting", "path", s.path, "error", cause)
+
+	lastErr := cause
+	for attempt := 1; s.policy.MaxAttempts == 0 || attempt <= s.policy.MaxAttempts; attempt++ {
+		timer := time.NewTimer(s.policy.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-s.done:
+			timer.Stop()
+			return nil, nil
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		conn, err := s.client.connect(ctx, s.path, s.apiKey)
+		if err != nil {
+			logger.Info("stream reconnect attempt failed", "path", s.path, "attempt", attempt, "error", err)
+			lastErr = err
+			continue
+		}
+
+		s.mu.Lock()
+		if s.closed() {
+			s.mu.Unlock()
+			conn.Close()
+			return nil, nil
+		}
+		s.conn = conn
+		s.mu.Unlock()
+		logger.Info("stream reconnected", "path", s.path, "attempt", attempt)
+		return conn, nil
+	}
+	logger.Warn("stream reconnect gave up", "path", s.path, "attempts", s.policy.MaxAttempts, "error", lastErr)
+	return nil, fmt.Errorf("stream %s: giving up after %d reconnect attempts: %w", s.path, s.policy.MaxAttempts, lastErr)
+}
+
+func (s *Subscription[T]) deliver(ctx context.Context, ch chan<- Message[T], msg Message[T]) bool {
+	select {
+	case ch <- msg:
+		return true
+	case <-s.done:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *Subscription[T]) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Subscription[T]) setErr(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Errors caused by Close are expected and not reported
+	if s.closed() {
+		return
+	}
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// Err returns the error that terminated the subscription, or nil if it is still running or was closed by the caller.
+func (s *Subscription[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close terminates the subscription and its underlying connection, stopping any reconnect attempts.
+func (s *Subscription[T]) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		close(s.done)
+		conn := s.conn
+		s.mu.Unlock()
+
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+		// The connection is already closed when it dropped and the subscription is reconnecting
+		if err = conn.Close(); errors.Is(err, net.ErrClosed) {
+			err = nil
+		}
+	})
+	return err
+}
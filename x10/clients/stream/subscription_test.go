@@ -0,0 +1,333 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+)
+
+// streamServer upgrades every request and hands the n-th connection (starting at 1) to serve.
+// A nil conn means serve rejected the handshake.
+func streamServer(t *testing.T, serve func(n int, w http.ResponseWriter, r *http.Request)) (*StreamClient, *atomic.Int32) {
+	t.Helper()
+	var connections atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serve(int(connections.Add(1)), w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewStreamClient(x10.Testnet(), clients.WithStreamURL("ws"+strings.TrimPrefix(srv.URL, "http")))
+	client.SetReconnectPolicy(ReconnectPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+	})
+	return client, &connections
+}
+
+func upgrade(t *testing.T, w http.ResponseWriter, r *http.Request) *websocket.Conn {
+	t.Helper()
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		t.Errorf("upgrade failed: %v", err)
+		return nil
+	}
+	return conn
+}
+
+func send(conn *websocket.Conn, seq int64, data int) {
+	_ = conn.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, `{"type":"DELTA","seq":%d,"data":%d}`, seq, data))
+}
+
+// drain reads until the server side is closed by the test or the client goes away.
+func drain(conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// next returns the next message of sub, failing the test if none arrives in time.
+func next[T any](t *testing.T, sub *Subscription[T]) Message[T] {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.C:
+		if !ok {
+			t.Fatalf("subscription ended: %v", sub.Err())
+		}
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message within 5s")
+	}
+	panic("unreachable")
+}
+
+// ended waits for sub.C to be closed.
+func ended[T any](t *testing.T, sub *Subscription[T]) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-sub.C:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("subscription did not end within 5s")
+		}
+	}
+}
+
+func TestSubscriptionDelivers(t *testing.T) {
+	client, _ := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if conn := upgrade(t, w, r); conn != nil {
+			defer conn.Close()
+			send(conn, 1, 7)
+			drain(conn)
+		}
+	})
+
+	sub, err := subscribe[int](context.Background(), client, "/test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.ActiveSubscriptions(); got != 1 {
+		t.Errorf("ActiveSubscriptions = %d, want 1", got)
+	}
+	if msg := next(t, sub); msg.Type != MessageTypeDelta || msg.Seq != 1 || msg.Data != 7 {
+		t.Errorf("got %+v, want DELTA 1 with 7", msg)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Errorf("Close = %v", err)
+	}
+	ended(t, sub)
+	if sub.Err() != nil {
+		t.Errorf("Err = %v, want nil after Close", sub.Err())
+	}
+}
+
+func TestSubscriptionDecodeErrorEnds(t *testing.T) {
+	client, connections := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if conn := upgrade(t, w, r); conn != nil {
+			defer conn.Close()
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"DELTA","data":"not a number"}`))
+			drain(conn)
+		}
+	})
+
+	sub, err := subscribe[int](context.Background(), client, "/test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	ended(t, sub)
+	if _, ok := sub.Err().(*decodeError); !ok {
+		t.Errorf("Err = %v, want a decode error", sub.Err())
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("connections = %d, want no reconnect after a decode error", got)
+	}
+}
+
+func TestSubscriptionReconnectsWithGap(t *testing.T) {
+	client, connections := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		conn := upgrade(t, w, r)
+		if conn == nil {
+			return
+		}
+		defer conn.Close()
+		send(conn, 1, n*10)
+		if n == 1 {
+			return // drop the first connection
+		}
+		drain(conn)
+	})
+
+	sub, err := subscribe[int](context.Background(), client, "/test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	want := []struct {
+		msgType string
+		data    int
+	}{
+		{MessageTypeDelta, 10},
+		{MessageTypeGap, 0},
+		{MessageTypeDelta, 20},
+	}
+	for _, w := range want {
+		if msg := next(t, sub); msg.Type != w.msgType || msg.Data != w.data {
+			t.Fatalf("got %s %d, want %s %d", msg.Type, msg.Data, w.msgType, w.data)
+		}
+	}
+	if got := connections.Load(); got != 2 {
+		t.Errorf("connections = %d, want 2", got)
+	}
+	if sub.Err() != nil {
+		t.Errorf("Err = %v, want nil while running", sub.Err())
+	}
+}
+
+func TestSubscriptionPongTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   bool // whether the first connection answers pings
+		wantType string
+	}{
+		{name: "silent server is dropped", answer: false, wantType: MessageTypeGap},
+		{name: "answered pings keep the connection", answer: true, wantType: MessageTypeDelta},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+
+			client, connections := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+				conn := upgrade(t, w, r)
+				if conn == nil {
+					return
+				}
+				defer conn.Close()
+				if n > 1 {
+					send(conn, 1, n)
+					drain(conn)
+					return
+				}
+				if tt.answer {
+					// Pings are answered while reading; send after several ping intervals
+					go drain(conn)
+					time.Sleep(300 * time.Millisecond)
+					send(conn, 1, n)
+				}
+				<-release
+			})
+			policy := client.reconnectPolicy()
+			policy.PingInterval = 40 * time.Millisecond
+			policy.PongTimeout = 40 * time.Millisecond
+			client.SetReconnectPolicy(policy)
+
+			sub, err := subscribe[int](context.Background(), client, "/test", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sub.Close()
+
+			if msg := next(t, sub); msg.Type != tt.wantType {
+				t.Fatalf("got %s, want %s", msg.Type, tt.wantType)
+			}
+			if !tt.answer {
+				if msg := next(t, sub); msg.Data != 2 {
+					t.Errorf("got data %d, want 2 from the new connection", msg.Data)
+				}
+				return
+			}
+			if got := connections.Load(); got != 1 {
+				t.Errorf("connections = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestSubscriptionCloseDuringReconnect(t *testing.T) {
+	dropped := make(chan struct{})
+	client, connections := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		conn := upgrade(t, w, r)
+		if conn == nil {
+			return
+		}
+		conn.Close()
+		close(dropped)
+	})
+	// Park the subscription in its reconnect backoff
+	client.SetReconnectPolicy(ReconnectPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Minute, Multiplier: 1})
+
+	sub, err := subscribe[int](context.Background(), client, "/test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-dropped
+	time.Sleep(50 * time.Millisecond)
+
+	if err := sub.Close(); err != nil {
+		t.Errorf("Close = %v, want nil", err)
+	}
+	ended(t, sub)
+	if sub.Err() != nil {
+		t.Errorf("Err = %v, want nil after Close", sub.Err())
+	}
+	if got := client.ActiveSubscriptions(); got != 0 {
+		t.Errorf("ActiveSubscriptions = %d, want 0", got)
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("connections = %d, want no reconnect after Close", got)
+	}
+}
+
+func TestSubscriptionGivesUpAfterMaxAttempts(t *testing.T) {
+	client, connections := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if n > 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if conn := upgrade(t, w, r); conn != nil {
+			conn.Close()
+		}
+	})
+	policy := client.reconnectPolicy()
+	policy.MaxAttempts = 3
+	client.SetReconnectPolicy(policy)
+
+	sub, err := subscribe[int](context.Background(), client, "/test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	ended(t, sub)
+	if err := sub.Err(); err == nil || !strings.Contains(err.Error(), "giving up after 3 reconnect attempts") {
+		t.Errorf("Err = %v, want giving up after 3 attempts", err)
+	}
+	if got := connections.Load(); got != 4 {
+		t.Errorf("connections = %d, want the initial one and 3 attempts", got)
+	}
+	if got := client.ActiveSubscriptions(); got != 0 {
+		t.Errorf("ActiveSubscriptions = %d, want 0", got)
+	}
+}
+
+func TestSubscriptionContextCancel(t *testing.T) {
+	client, _ := streamServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if conn := upgrade(t, w, r); conn != nil {
+			defer conn.Close()
+			drain(conn)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := subscribe[int](ctx, client, "/test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	cancel()
+	ended(t, sub)
+	if err := sub.Err(); err != context.Canceled {
+		t.Errorf("Err = %v, want context.Canceled", err)
+	}
+}
@@ -3,11 +3,15 @@ package trading
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
 	pub "github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients/public"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
 )
 
@@ -17,28 +21,89 @@ import (
 type TradingClient struct {
 	*pub.PublicClient
 	httpClient *clients.HTTPClient
+	config     *x10.Config
 	streaming  bool
 	account    *starknet.StarknetPerpetualAccount
-	markets    map[string]*info.Market   // Cached market data
+	markets    map[string]*info.Market // Cached market data
+
+	marketStateChecks bool // fetch the mark price and open orders before signing, see SetMarketStateChecks
+
+	feesMu          sync.Mutex
+	fees            map[string]user.TradingFee // fee rates orders are signed with, per market
+	feesFetchedAt   map[string]time.Time       // when fees was last loaded, per market
+	feesRefreshTime time.Duration
 }
 
-// NewTradingClient creates a new TradingClient by loading credentials from environment variables.
+// DefaultFeesRefreshInterval is how long trading fees loaded via GetFees are reused before being fetched again.
+const DefaultFeesRefreshInterval = time.Hour
+
+// NewTradingClient creates a new TradingClient by loading credentials from environment variables,
+// unless an account is passed with clients.WithAccount. The remaining opts customise the HTTP and
+// stream transports (e.g. clients.WithHTTPClient, clients.WithTimeout, clients.WithLogger).
 // This is the main constructor that matches the Python SDK's approach.
-func NewTradingClient(cfg *x10.Config, enableStreaming bool) (*TradingClient, error) {
-	account, err := starknet.NewStarknetAccount()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load Starknet account from environment: %w", err)
+func NewTradingClient(cfg *x10.Config, enableStreaming bool, opts ...clients.Option) (*TradingClient, error) {
+	account := clients.ResolveOptions(cfg, opts...).Account
+	if account == nil {
+		var err error
+		account, err = starknet.NewStarknetAccount()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Starknet account from environment: %w", err)
+		}
 	}
 
+	return NewTradingClientWithAccount(cfg, account, enableStreaming, opts...)
+}
+
+// NewTradingClientWithAccount creates a new TradingClient for an already constructed account.
+// Use it to plug in a custom starknet.Signer (e.g. a RemoteSigner) instead of an in-memory private key.
+func NewTradingClientWithAccount(cfg *x10.Config, account *starknet.StarknetPerpetualAccount, enableStreaming bool, opts ...clients.Option) (*TradingClient, error) {
+	if account == nil || account.Signer == nil {
+		return nil, fmt.Errorf("account with a signer is required")
+	}
+
+	// The public and private endpoints count against the same exchange limit, so they share a limiter
+	opts = append(opts, clients.WithRateLimiter(clients.ResolveOptions(cfg, opts...).Limiter))
+
 	return &TradingClient{
-		PublicClient: pub.NewPublicClient(cfg, enableStreaming),
-		httpClient:   clients.NewHTTPClientWithAPIKey(cfg, account.APIKey),
+		PublicClient: pub.NewPublicClient(cfg, enableStreaming, opts...),
+		httpClient:   clients.NewHTTPClientWithAPIKey(cfg, account.APIKey, opts...),
+		config:       cfg,
 		streaming:    enableStreaming,
 		account:      account,
 		markets:      make(map[string]*info.Market), // Initialize market cache
+
+		fees:            make(map[string]user.TradingFee),
+		feesFetchedAt:   make(map[string]time.Time),
+		feesRefreshTime: DefaultFeesRefreshInterval,
 	}, nil
 }
 
+// SetRetryPolicy sets how failed REST requests are retried, for both public and private endpoints.
+func (c *TradingClient) SetRetryPolicy(policy clients.RetryPolicy) {
+	c.PublicClient.SetRetryPolicy(policy)
+	c.httpClient.SetRetryPolicy(policy)
+}
+
+// SetRateLimiter sets the client-side rate limiter for public and private REST requests; nil disables it.
+func (c *TradingClient) SetRateLimiter(limiter *clients.TokenBucket) {
+	c.PublicClient.SetRateLimiter(limiter)
+	c.httpClient.SetRateLimiter(limiter)
+}
+
+// SetLogger sets the logger for public and private REST requests and streams; nil discards them.
+func (c *TradingClient) SetLogger(logger *slog.Logger) {
+	c.PublicClient.SetLogger(logger)
+	c.httpClient.SetLogger(logger)
+}
+
+// SetMarketStateChecks sets whether orders are checked against the market's current state before they are
+// signed: the mark price (LimitPriceCap / LimitPriceFloor) and the number of open orders (MaxNumOrders).
+// When enabled, each order costs up to two extra REST requests unless PlaceOrderOptions supplies MarkPrice
+// and OpenOrders, e.g. from a stream. Disabled by default; supplied state is checked either way.
+func (c *TradingClient) SetMarketStateChecks(enabled bool) {
+	c.marketStateChecks = enabled
+}
+
 func (c *TradingClient) StreamingEnabled() bool {
 	return c.streaming
 }
@@ -0,0 +1,151 @@
+package trading
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// testClient returns a TradingClient for srv with fast retries and no rate limiting.
+func testClient(t *testing.T, srv *httptest.Server) *TradingClient {
+	t.Helper()
+	privateKey, _ := new(big.Int).SetString("7a7ff6fd3cab02ccdcd4a572563f5976f8976899b03a39773795a3c486d4986", 16)
+	signer, err := starknet.NewPrivateKeySigner(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := starknet.NewStarknetAccountWithSigner(10002, "test-api-key", signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewTradingClientWithAccount(x10.Testnet(), account, false, clients.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetRateLimiter(nil)
+	client.SetRetryPolicy(clients.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2})
+	return client
+}
+
+// fakeExchange serves the endpoints the order methods call for the BTC-USD market and records the requests.
+type fakeExchange struct {
+	mu         sync.Mutex
+	calls      map[string]int // by "METHOD path"
+	orders     []user.CreateOrderRequest
+	fees       user.TradingFee
+	markPrice  string
+	openOrders int
+}
+
+func newFakeExchange(t *testing.T) (*fakeExchange, *TradingClient) {
+	t.Helper()
+	ex := &fakeExchange{
+		calls: map[string]int{},
+		fees: user.TradingFee{
+			Market:         "BTC-USD",
+			MakerFeeRate:   decimal.RequireFromString("0.0002"),
+			TakerFeeRate:   decimal.RequireFromString("0.0005"),
+			BuilderFeeRate: decimal.RequireFromString("0.001"),
+		},
+		markPrice: "50000",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(ex.serve))
+	t.Cleanup(srv.Close)
+	return ex, testClient(t, srv)
+}
+
+func (ex *fakeExchange) serve(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+	ex.calls[r.Method+" "+r.URL.Path]++
+
+	var data any
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/info/markets":
+		data = []info.Market{testMarket()}
+	case r.Method == http.MethodGet && r.URL.Path == "/info/markets/BTC-USD/stats":
+		data = map[string]string{"markPrice": ex.markPrice}
+	case r.Method == http.MethodGet && r.URL.Path == "/info/markets/BTC-USD/orderbook":
+		data = info.OrderBook{
+			Market: "BTC-USD",
+			Bid:    []info.OrderBookEntry{{Qty: decimal.RequireFromString("1"), Price: decimal.RequireFromString("49990")}},
+			Ask:    []info.OrderBookEntry{{Qty: decimal.RequireFromString("1"), Price: decimal.RequireFromString("50010")}},
+		}
+	case r.Method == http.MethodGet && r.URL.Path == "/user/fees":
+		data = []user.TradingFee{ex.fees}
+	case r.Method == http.MethodGet && r.URL.Path == "/user/orders":
+		data = make([]user.Order, ex.openOrders)
+	case r.Method == http.MethodPost && r.URL.Path == "/user/order":
+		var req user.CreateOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ex.orders = append(ex.orders, req)
+		data = user.CreateOrderResponse{ID: int64(len(ex.orders)), ExternalID: req.ID}
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/user/order/"):
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"status": "OK", "data": data})
+}
+
+// count returns how many requests were made to "METHOD path".
+func (ex *fakeExchange) count(call string) int {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+	return ex.calls[call]
+}
+
+// placed returns the order requests posted so far.
+func (ex *fakeExchange) placed() []user.CreateOrderRequest {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+	return append([]user.CreateOrderRequest(nil), ex.orders...)
+}
+
+// testMarket is a BTC-USD market with the testnet L2 config.
+func testMarket() info.Market {
+	return info.Market{
+		Name:                     "BTC-USD",
+		AssetName:                "BTC",
+		AssetPrecision:           5,
+		CollateralAssetName:      "USD",
+		CollateralAssetPrecision: 6,
+		Active:                   true,
+		Status:                   "ACTIVE",
+		TradingConfig: info.TradingConfig{
+			MinOrderSize:        decimal.RequireFromString("0.0001"),
+			MinOrderSizeChange:  decimal.RequireFromString("0.0001"),
+			MinPriceChange:      decimal.RequireFromString("1"),
+			MaxMarketOrderValue: decimal.RequireFromString("1000000"),
+			MaxLimitOrderValue:  decimal.RequireFromString("5000000"),
+			MaxPositionValue:    decimal.RequireFromString("10000000"),
+			MaxLeverage:         decimal.RequireFromString("50"),
+			MaxNumOrders:        "200",
+			LimitPriceCap:       decimal.RequireFromString("0.05"),
+			LimitPriceFloor:     decimal.RequireFromString("0.05"),
+		},
+		L2Config: info.L2Config{
+			Type:                 "STARKX",
+			CollateralID:         "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+			CollateralResolution: 1000000,
+			SyntheticID:          "0x4254432d3600000000000000000000",
+			SyntheticResolution:  1000000,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
+)
+
+// SetFeesRefreshInterval sets how long loaded trading fees are reused before GetFees is called again.
+// A non-positive interval loads the fees once per market and never refreshes them.
+func (c *TradingClient) SetFeesRefreshInterval(interval time.Duration) {
+	c.feesMu.Lock()
+	defer c.feesMu.Unlock()
+	c.feesRefreshTime = interval
+}
+
+// RefreshTradingFees fetches the sub-account's fee rates for market. The client signs its orders
+// with them until the refresh interval has passed.
+func (c *TradingClient) RefreshTradingFees(ctx context.Context, market string) (*user.TradingFee, error) {
+	fees, err := c.GetFees(ctx, &market, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fee := range fees {
+		if fee.Market != market {
+			continue
+		}
+
+		c.feesMu.Lock()
+		c.fees[market] = fee
+		c.feesFetchedAt[market] = time.Now()
+		c.feesMu.Unlock()
+		return &fee, nil
+	}
+	return nil, fmt.Errorf("no fees returned for market %s", market)
+}
+
+// tradingFee returns the fees for market, loading them unless they were loaded within the refresh interval.
+func (c *TradingClient) tradingFee(ctx context.Context, market string) (user.TradingFee, error) {
+	c.feesMu.Lock()
+	fee, loaded := c.fees[market]
+	fetchedAt := c.feesFetchedAt[market]
+	fresh := loaded && (c.feesRefreshTime <= 0 || time.Since(fetchedAt) < c.feesRefreshTime)
+	c.feesMu.Unlock()
+
+	if fresh {
+		return fee, nil
+	}
+	refreshed, err := c.RefreshTradingFees(ctx, market)
+	if err != nil {
+		return user.TradingFee{}, fmt.Errorf("failed to load trading fees for %s: %w", market, err)
+	}
+	return *refreshed, nil
+}
+
+// withTradingFee returns a copy of opts that signs the order with the client's fees for market,
+// unless opts already sets TradingFee. The shared account is never modified.
+func (c *TradingClient) withTradingFee(ctx context.Context, market string, opts *perpetual.PlaceOrderOptions) (*perpetual.PlaceOrderOptions, error) {
+	filled := perpetual.PlaceOrderOptions{}
+	if opts != nil {
+		filled = *opts
+	}
+	if filled.TradingFee == nil {
+		fee, err := c.tradingFee(ctx, market)
+		if err != nil {
+			return nil, err
+		}
+		filled.TradingFee = &fee
+	}
+	return &filled, nil
+}
@@ -0,0 +1,93 @@
+package trading
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
+	"github.com/shopspring/decimal"
+)
+
+func TestTradingFeeRefreshInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		interval  time.Duration
+		wantLoads int
+		wantFee   string // taker fee of the second order, after the exchange changed it
+	}{
+		{name: "within the interval", interval: time.Hour, wantLoads: 1, wantFee: "0.0005"},
+		{name: "never refreshed", interval: 0, wantLoads: 1, wantFee: "0.0005"},
+		{name: "after the interval", interval: time.Millisecond, wantLoads: 2, wantFee: "0.0004"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex, client := newFakeExchange(t)
+			client.SetFeesRefreshInterval(tt.interval)
+			qty, price := decimal.RequireFromString("0.01"), decimal.RequireFromString("50000")
+
+			if _, err := client.PlaceOrder(context.Background(), "BTC-USD", qty, price, "BUY", nil); err != nil {
+				t.Fatal(err)
+			}
+			ex.mu.Lock()
+			ex.fees.TakerFeeRate = decimal.RequireFromString("0.0004")
+			ex.mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			if _, err := client.PlaceOrder(context.Background(), "BTC-USD", qty, price, "BUY", nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := ex.count("GET /user/fees"); got != tt.wantLoads {
+				t.Errorf("loaded fees %d times, want %d", got, tt.wantLoads)
+			}
+			placed := ex.placed()
+			if got := placed[1].Fee; !got.Equal(decimal.RequireFromString(tt.wantFee)) {
+				t.Errorf("second order signed with fee %s, want %s", got, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestTradingFeeRate(t *testing.T) {
+	postOnly := true
+	custom := user.TradingFee{Market: "BTC-USD", MakerFeeRate: decimal.RequireFromString("0.0001"), TakerFeeRate: decimal.RequireFromString("0.0003")}
+
+	tests := []struct {
+		name      string
+		opts      *perpetual.PlaceOrderOptions
+		wantFee   string
+		wantLoads int
+	}{
+		{name: "taker", wantFee: "0.0005", wantLoads: 1},
+		{name: "post-only signs the maker rate", opts: &perpetual.PlaceOrderOptions{PostOnly: &postOnly}, wantFee: "0.0002", wantLoads: 1},
+		{name: "fee from opts", opts: &perpetual.PlaceOrderOptions{TradingFee: &custom}, wantFee: "0.0003"},
+		{name: "post-only fee from opts", opts: &perpetual.PlaceOrderOptions{TradingFee: &custom, PostOnly: &postOnly}, wantFee: "0.0001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex, client := newFakeExchange(t)
+
+			_, err := client.PlaceOrder(context.Background(), "BTC-USD", decimal.RequireFromString("0.01"), decimal.RequireFromString("50000"), "BUY", tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ex.placed()[0].Fee; !got.Equal(decimal.RequireFromString(tt.wantFee)) {
+				t.Errorf("signed with fee %s, want %s", got, tt.wantFee)
+			}
+			if got := ex.count("GET /user/fees"); got != tt.wantLoads {
+				t.Errorf("loaded fees %d times, want %d", got, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestRefreshTradingFeesUnknownMarket(t *testing.T) {
+	_, client := newFakeExchange(t)
+
+	if _, err := client.RefreshTradingFees(context.Background(), "ETH-USD"); err == nil {
+		t.Fatal("expected an error when the exchange returns no fees for the market")
+	}
+}
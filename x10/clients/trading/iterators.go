@@ -0,0 +1,99 @@
+package trading
+
+import (
+	"context"
+	"iter"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+)
+
+// TradesFilter selects the trades returned by AllTrades. Empty fields do not filter.
+type TradesFilter struct {
+	Markets []string
+	Type    *string // TRADE | LIQUIDATION | DELEVERAGE
+	Side    *string // BUY | SELL
+}
+
+// OrdersHistoryFilter selects the orders returned by AllOrdersHistory. Empty fields do not filter.
+type OrdersHistoryFilter struct {
+	Markets     []string
+	Type        *string
+	Side        *string
+	IDs         []int64
+	ExternalIDs []string
+}
+
+// PositionsHistoryFilter selects the positions returned by AllPositionsHistory. Empty fields do not filter.
+type PositionsHistoryFilter struct {
+	Markets []string
+	Side    *string // LONG | SHORT
+}
+
+// FundingPaymentsFilter selects the payments returned by AllFundingPayments. FromTime is required.
+type FundingPaymentsFilter struct {
+	FromTime int64 // epoch milliseconds
+	Markets  []string
+	Side     *string // LONG | SHORT
+}
+
+// AssetOperationsFilter selects the operations returned by AllAssetOperations. Empty fields do not filter.
+type AssetOperationsFilter struct {
+	Type   *string // DEPOSIT | WITHDRAWAL | TRANSFER | CLAIM
+	Status *string
+}
+
+// AllTrades iterates over the trades history, following the pagination cursor (see clients.Paginate):
+//
+//	for trade, err := range client.AllTrades(ctx, trading.TradesFilter{}, clients.PageOptions{PageSize: 100}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *TradingClient) AllTrades(ctx context.Context, filter TradesFilter, page clients.PageOptions) iter.Seq2[user.Trade, error] {
+	return clients.Paginate(ctx, page, func(ctx context.Context, cursor *int64, limit *int) ([]user.Trade, int64, error) {
+		trades, pagination, err := c.GetTrades(ctx, filter.Type, filter.Side, cursor, limit, filter.Markets...)
+		return trades, nextCursor(pagination), err
+	})
+}
+
+// AllOrdersHistory iterates over the orders history, following the pagination cursor.
+func (c *TradingClient) AllOrdersHistory(ctx context.Context, filter OrdersHistoryFilter, page clients.PageOptions) iter.Seq2[user.Order, error] {
+	return clients.Paginate(ctx, page, func(ctx context.Context, cursor *int64, limit *int) ([]user.Order, int64, error) {
+		orders, pagination, err := c.GetOrdersHistory(ctx, filter.Type, filter.Side, filter.IDs, filter.ExternalIDs, cursor, limit, filter.Markets...)
+		return orders, nextCursor(pagination), err
+	})
+}
+
+// AllPositionsHistory iterates over the positions history, following the pagination cursor.
+func (c *TradingClient) AllPositionsHistory(ctx context.Context, filter PositionsHistoryFilter, page clients.PageOptions) iter.Seq2[user.PositionHistory, error] {
+	return clients.Paginate(ctx, page, func(ctx context.Context, cursor *int64, limit *int) ([]user.PositionHistory, int64, error) {
+		positions, pagination, err := c.GetPositionsHistory(ctx, filter.Side, cursor, limit, filter.Markets...)
+		return positions, nextCursor(pagination), err
+	})
+}
+
+// AllFundingPayments iterates over the funding payments history, following the pagination cursor.
+func (c *TradingClient) AllFundingPayments(ctx context.Context, filter FundingPaymentsFilter, page clients.PageOptions) iter.Seq2[user.FundingPayment, error] {
+	return clients.Paginate(ctx, page, func(ctx context.Context, cursor *int64, limit *int) ([]user.FundingPayment, int64, error) {
+		payments, pagination, err := c.GetFundingPayments(ctx, filter.FromTime, filter.Side, cursor, limit, filter.Markets...)
+		return payments, nextCursor(pagination), err
+	})
+}
+
+// AllAssetOperations iterates over deposits, withdrawals and transfers, following the pagination cursor.
+func (c *TradingClient) AllAssetOperations(ctx context.Context, filter AssetOperationsFilter, page clients.PageOptions) iter.Seq2[user.AssetOperation, error] {
+	return clients.Paginate(ctx, page, func(ctx context.Context, cursor *int64, limit *int) ([]user.AssetOperation, int64, error) {
+		operations, pagination, err := c.GetAssetOperations(ctx, filter.Type, filter.Status, cursor, limit)
+		return operations, nextCursor(pagination), err
+	})
+}
+
+// nextCursor returns the cursor of the next page, or zero when there is none.
+func nextCursor(pagination *user.Pagination) int64 {
+	if pagination == nil {
+		return 0
+	}
+	return pagination.Cursor
+}
@@ -3,15 +3,73 @@ package trading
 import (
 	"context"
 	"fmt"
+	"net/url"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
+	"github.com/shopspring/decimal"
 )
 
 type updateLeverageRequest struct {
-	Market   string `json:"market"`
-	Leverage string `json:"leverage"`
+	Market   string          `json:"market"`
+	Leverage decimal.Decimal `json:"leverage"`
+}
+
+// GetLeverage returns the account's leverage per market. With no markets, all markets are returned.
+func (c *TradingClient) GetLeverage(ctx context.Context, markets ...string) ([]user.AccountLeverage, error) {
+	base := "/user/leverage"
+	q := url.Values{}
+	for _, m := range markets {
+		if m != "" {
+			q.Add("market", m)
+		}
+	}
+	endpoint := base
+	if encoded := q.Encode(); encoded != "" {
+		endpoint = base + "?" + encoded
+	}
+
+	var response struct {
+		Status string                 `json:"status"`
+		Data   []user.AccountLeverage `json:"data"`
+	}
+
+	if err := c.httpClient.Get(ctx, endpoint, &response); err != nil {
+		return nil, fmt.Errorf("failed to get leverage: %w", err)
+	}
+	return response.Data, nil
 }
 
 // UpdateLeverage updates leverage for an individual market.
-func (c *TradingClient) UpdateLeverage(ctx context.Context, market string, leverage string) error {
+// The leverage is checked against the market's risk factor tier for the current position value
+// first; a leverage above it fails with a *perpetual.ValidationError (perpetual.ErrLeverageTooHigh)
+// and no request is sent.
+func (c *TradingClient) UpdateLeverage(ctx context.Context, market string, leverage decimal.Decimal) error {
+	mkt, err := c.FetchMarketData(ctx, market)
+	if err != nil {
+		return err
+	}
+
+	positions, err := c.GetPositions(ctx, nil, market)
+	if err != nil {
+		return err
+	}
+	positionValue := decimal.Zero
+	for _, p := range positions {
+		if p.Market != market || p.Value == "" {
+			continue
+		}
+		value, err := decimal.NewFromString(p.Value)
+		if err != nil {
+			return fmt.Errorf("invalid position value %q: %w", p.Value, err)
+		}
+		positionValue = positionValue.Add(value.Abs())
+	}
+
+	if err := perpetual.ValidateLeverage(mkt, leverage, positionValue); err != nil {
+		return err
+	}
+
 	endpoint := "/user/leverage"
 	req := updateLeverageRequest{Market: market, Leverage: leverage}
 
@@ -2,8 +2,15 @@ package trading
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
 	"github.com/shopspring/decimal"
@@ -11,6 +18,8 @@ import (
 
 // PlaceOrder creates and submits a LIMIT order, matching Python's place_order method.
 // This is the main entrypoint for placing orders on the exchange.
+// The order is checked against LimitPriceCap / LimitPriceFloor and MaxNumOrders when opts supplies
+// MarkPrice and OpenOrders, or when they are fetched because SetMarketStateChecks is enabled.
 func (c *TradingClient) PlaceOrder(ctx context.Context, market string, amountOfSynthetic decimal.Decimal, price decimal.Decimal, side string, opts *perpetual.PlaceOrderOptions) (*user.CreateOrderResponse, error) {
 	if c.account == nil {
 		return nil, fmt.Errorf("stark account is not set")
@@ -21,7 +30,169 @@ func (c *TradingClient) PlaceOrder(ctx context.Context, market string, amountOfS
 		return nil, err
 	}
 
-	req, err := perpetual.CreateOrder(c.account, mkt, amountOfSynthetic, price, side, opts)
+	opts, err = c.withTradingFee(ctx, market, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateOptions(ctx, market, opts); err != nil {
+		return nil, err
+	}
+
+	opts, err = c.withMarketState(ctx, market, "LIMIT", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := perpetual.CreateOrder(c.account.WithContext(ctx), mkt, amountOfSynthetic, price, side, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PlaceOrderPostRequest(ctx, *req)
+}
+
+// ValidateBuilderFee checks builderFee against the maximum builder fee rate the exchange reports
+// via GetFees for market and builderID.
+func (c *TradingClient) ValidateBuilderFee(ctx context.Context, market string, builderID int, builderFee decimal.Decimal) error {
+	builder := strconv.Itoa(builderID)
+	fees, err := c.GetFees(ctx, &market, &builder)
+	if err != nil {
+		return err
+	}
+
+	for _, fee := range fees {
+		if fee.Market != market {
+			continue
+		}
+		if builderFee.GreaterThan(fee.BuilderFeeRate) {
+			return fmt.Errorf("builder fee %s exceeds max builder fee rate %s for builder %d on %s", builderFee, fee.BuilderFeeRate, builderID, market)
+		}
+		return nil
+	}
+	return fmt.Errorf("no fees found for builder %d on %s", builderID, market)
+}
+
+// validateOptions performs the checks on opts that need the exchange, before an order is signed.
+func (c *TradingClient) validateOptions(ctx context.Context, market string, opts *perpetual.PlaceOrderOptions) error {
+	if opts == nil || opts.BuilderFee == nil || opts.BuilderID == nil {
+		return nil
+	}
+	return c.ValidateBuilderFee(ctx, market, *opts.BuilderID, *opts.BuilderFee)
+}
+
+// withMarketState returns a copy of opts. With SetMarketStateChecks enabled, the MarkPrice and
+// OpenOrders that opts does not set are fetched from the exchange, as far as they apply to orderType:
+// a CONDITIONAL order is priced against the mark at activation rather than now, a MARKET order never
+// rests, and a replacement order (PreviousOrderID) does not add to the open orders.
+func (c *TradingClient) withMarketState(ctx context.Context, market string, orderType string, opts *perpetual.PlaceOrderOptions) (*perpetual.PlaceOrderOptions, error) {
+	filled := perpetual.PlaceOrderOptions{}
+	if opts != nil {
+		filled = *opts
+	}
+	if !c.marketStateChecks {
+		return &filled, nil
+	}
+
+	if filled.MarkPrice == nil && orderType != "CONDITIONAL" {
+		stats, err := c.GetMarketStats(ctx, market)
+		if err != nil {
+			return nil, err
+		}
+		filled.MarkPrice = &stats.MarkPrice
+	}
+
+	if filled.OpenOrders == nil && filled.PreviousOrderID == nil && orderType != "MARKET" {
+		orders, err := c.GetOpenOrders(ctx, nil, nil, market)
+		if err != nil {
+			return nil, err
+		}
+		openOrders := len(orders)
+		filled.OpenOrders = &openOrders
+	}
+	return &filled, nil
+}
+
+// PlaceMarketOrder creates and submits a MARKET order for qty, protected by a limit price at most
+// maxSlippage (e.g. 0.01 for 1%) away from the current top of book, or from the mark price when the
+// book has no liquidity on the taker side. The order is signed as IOC, so any unfilled part is cancelled.
+func (c *TradingClient) PlaceMarketOrder(ctx context.Context, market string, qty decimal.Decimal, side string, maxSlippage decimal.Decimal) (*user.CreateOrderResponse, error) {
+	if c.account == nil {
+		return nil, fmt.Errorf("stark account is not set")
+	}
+
+	mkt, err := c.FetchMarketData(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := c.withTradingFee(ctx, market, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := c.GetOrderBook(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := book.TakerPrice(side); !ok {
+		stats, err := c.GetMarketStats(ctx, market)
+		if err != nil {
+			return nil, err
+		}
+		opts.MarkPrice = &stats.MarkPrice
+	}
+
+	opts, err = c.withMarketState(ctx, market, "MARKET", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	markPrice := decimal.Zero
+	if opts.MarkPrice != nil {
+		markPrice = *opts.MarkPrice
+	}
+	price, err := perpetual.MarketOrderPrice(mkt, book, markPrice, qty, side, maxSlippage)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := perpetual.CreateMarketOrder(c.account.WithContext(ctx), mkt, qty, price, side, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PlaceOrderPostRequest(ctx, *req)
+}
+
+// PlaceConditionalOrder creates and submits a CONDITIONAL order that is activated once trigger is hit.
+// price is the limit price for LIMIT execution, or the protective worst price for MARKET execution.
+func (c *TradingClient) PlaceConditionalOrder(ctx context.Context, market string, amountOfSynthetic decimal.Decimal, price decimal.Decimal, side string, trigger perpetual.TriggerParams, opts *perpetual.PlaceOrderOptions) (*user.CreateOrderResponse, error) {
+	if c.account == nil {
+		return nil, fmt.Errorf("stark account is not set")
+	}
+
+	mkt, err := c.FetchMarketData(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = c.withTradingFee(ctx, market, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateOptions(ctx, market, opts); err != nil {
+		return nil, err
+	}
+
+	opts, err = c.withMarketState(ctx, market, "CONDITIONAL", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := perpetual.CreateConditionalOrder(c.account.WithContext(ctx), mkt, amountOfSynthetic, price, side, trigger, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -40,11 +211,268 @@ func (c *TradingClient) PlaceOrderPostRequest(ctx context.Context, req user.Crea
 		Data   user.CreateOrderResponse `json:"data"`
 	}
 
-	if err := c.httpClient.Post(ctx, endpoint, req, &response); err != nil {
-		return nil, fmt.Errorf("failed to create/edit order: %w", err)
+	// A signed order with a fixed ID and nonce can be resubmitted safely: the exchange rejects duplicates,
+	// and a duplicate rejection on a retry is resolved by findRetriedOrder
+	post := c.httpClient.Post
+	if req.ID != "" && req.Nonce != "" && req.Settlement.Signature.R != "" {
+		post = c.httpClient.PostIdempotent
+	}
+
+	if err := post(ctx, endpoint, req, &response); err != nil {
+		if placed := c.findRetriedOrder(ctx, req, err); placed != nil {
+			return placed, nil
+		}
+		return nil, fmt.Errorf("failed to create/edit order: %w", asOrderRejection(err, req.ID))
 	}
 	if response.Status != "OK" {
-		return nil, fmt.Errorf("failed to create/edit order: status=%s", response.Status)
+		return nil, fmt.Errorf("failed to create/edit order: %w", &models.OrderRejectedError{
+			X10Error: &models.X10Error{Message: fmt.Sprintf("status=%s", response.Status), HTTPStatus: http.StatusOK},
+			OrderID:  req.ID,
+			Reason:   user.OrderStatusReasonUnknown,
+		})
 	}
 	return &response.Data, nil
 }
+
+// findRetriedOrder resolves a rejection received on a retry of req: when an earlier attempt was
+// accepted but its response lost, the retry is rejected as a duplicate although the order is live.
+// It returns the live order with req's external ID, or nil when there is none.
+func (c *TradingClient) findRetriedOrder(ctx context.Context, req user.CreateOrderRequest, err error) *user.CreateOrderResponse {
+	var retried *clients.RetriedError
+	var apiErr *models.X10Error
+	if !errors.As(err, &retried) || !errors.As(err, &apiErr) {
+		return nil
+	}
+	if apiErr.HTTPStatus < 400 || apiErr.HTTPStatus >= 500 {
+		return nil
+	}
+
+	orders, lookupErr := c.GetOrdersByExternalID(ctx, req.ID)
+	if lookupErr != nil {
+		return nil
+	}
+	for _, order := range orders {
+		if order.ExternalID == req.ID && order.Market == req.Market && order.Side == req.Side {
+			return &user.CreateOrderResponse{ID: order.ID, ExternalID: order.ExternalID}
+		}
+	}
+	return nil
+}
+
+// asOrderRejection turns an exchange error from the place/edit order endpoint into *models.OrderRejectedError.
+// Authentication, not found and rate limit errors are returned unchanged.
+func asOrderRejection(err error, orderID string) error {
+	var apiErr *models.X10Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch apiErr.HTTPStatus {
+	case http.StatusUnauthorized, http.StatusNotFound, http.StatusTooManyRequests:
+		return err
+	}
+	if apiErr.HTTPStatus >= 500 {
+		return err
+	}
+	reason := user.ParseOrderStatusReason(apiErr.Message)
+	if reason == user.OrderStatusReasonUnknown {
+		reason = user.ParseOrderStatusReason(apiErr.Details)
+	}
+	return &models.OrderRejectedError{X10Error: apiErr, OrderID: orderID, Reason: reason}
+}
+
+type massCancelRequest struct {
+	OrderIDs         []int64  `json:"orderIds,omitempty"`
+	ExternalOrderIDs []string `json:"externalOrderIds,omitempty"`
+	Markets          []string `json:"markets,omitempty"`
+	CancelAll        bool     `json:"cancelAll,omitempty"`
+}
+
+// CancelOrder cancels a single open order by its exchange-assigned ID.
+func (c *TradingClient) CancelOrder(ctx context.Context, orderID int64) error {
+	endpoint := fmt.Sprintf("/user/order/%d", orderID)
+
+	var response struct {
+		Status string `json:"status"`
+	}
+
+	if err := c.httpClient.Delete(ctx, endpoint, &response); err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+	if response.Status != "" && response.Status != "OK" {
+		return fmt.Errorf("failed to cancel order: status=%s", response.Status)
+	}
+	return nil
+}
+
+// CancelOrderByExternalID cancels a single open order by the user-provided external ID.
+func (c *TradingClient) CancelOrderByExternalID(ctx context.Context, externalID string) error {
+	q := url.Values{}
+	q.Set("externalId", externalID)
+	endpoint := "/user/order?" + q.Encode()
+
+	var response struct {
+		Status string `json:"status"`
+	}
+
+	if err := c.httpClient.Delete(ctx, endpoint, &response); err != nil {
+		return fmt.Errorf("failed to cancel order by external id: %w", err)
+	}
+	if response.Status != "" && response.Status != "OK" {
+		return fmt.Errorf("failed to cancel order by external id: status=%s", response.Status)
+	}
+	return nil
+}
+
+// MassCancel cancels open orders matching any of the given markets, order IDs or external IDs.
+// Set cancelAll to cancel every open order of the sub-account regardless of the other filters.
+func (c *TradingClient) MassCancel(ctx context.Context, markets []string, orderIDs []int64, externalIDs []string, cancelAll bool) error {
+	if !cancelAll && len(markets) == 0 && len(orderIDs) == 0 && len(externalIDs) == 0 {
+		return fmt.Errorf("at least one filter must be provided or cancelAll must be set")
+	}
+
+	endpoint := "/user/order/massCancel"
+	req := massCancelRequest{
+		OrderIDs:         orderIDs,
+		ExternalOrderIDs: externalIDs,
+		Markets:          markets,
+		CancelAll:        cancelAll,
+	}
+
+	var response struct {
+		Status string `json:"status"`
+	}
+
+	if err := c.httpClient.Post(ctx, endpoint, req, &response); err != nil {
+		return fmt.Errorf("failed to mass cancel orders: %w", err)
+	}
+	if response.Status != "" && response.Status != "OK" {
+		return fmt.Errorf("failed to mass cancel orders: status=%s", response.Status)
+	}
+	return nil
+}
+
+// EditOrderResult describes the replacement order created by EditOrder.
+type EditOrderResult struct {
+	ID         int64
+	ExternalID string
+	// Atomic is true when the exchange cancelled the existing order and accepted the replacement
+	// in a single request. When false, the existing order was cancelled first and the replacement
+	// placed separately, so the position was briefly without the order.
+	Atomic bool
+}
+
+// EditOrder replaces an open LIMIT order with one at newPrice for newQty, keeping its side, flags,
+// TP/SL legs, builder fee and self-trade protection level. The replacement is signed with a fresh nonce
+// and sent with the existing external ID as its cancel ID, which the exchange applies atomically.
+// Orders without an external ID cannot be replaced atomically and are cancelled by ID before the
+// replacement is placed.
+func (c *TradingClient) EditOrder(ctx context.Context, existing user.Order, newPrice decimal.Decimal, newQty decimal.Decimal) (*EditOrderResult, error) {
+	if existing.Type != "LIMIT" {
+		return nil, fmt.Errorf("only LIMIT orders can be edited, got %s", existing.Type)
+	}
+	switch existing.Status {
+	case "NEW", "PARTIALLY_FILLED", "UNTRIGGERED":
+	default:
+		return nil, fmt.Errorf("order %d is not open: status=%s", existing.ID, existing.Status)
+	}
+	if !newPrice.IsPositive() || !newQty.IsPositive() {
+		return nil, fmt.Errorf("new price and qty must be positive")
+	}
+
+	opts, err := replacementOptions(existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy order %d: %w", existing.ID, err)
+	}
+
+	atomic := existing.ExternalID != ""
+	if atomic {
+		previousID := existing.ExternalID
+		opts.PreviousOrderID = &previousID
+	} else if err := c.CancelOrder(ctx, existing.ID); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PlaceOrder(ctx, existing.Market, newQty, newPrice, existing.Side, opts)
+	if err != nil {
+		if atomic {
+			return nil, fmt.Errorf("failed to replace order %s: %w", existing.ExternalID, err)
+		}
+		return nil, fmt.Errorf("order %d was cancelled but its replacement failed: %w", existing.ID, err)
+	}
+	return &EditOrderResult{ID: resp.ID, ExternalID: resp.ExternalID, Atomic: atomic}, nil
+}
+
+// replacementOptions returns the options that place an order with the same settings as existing.
+func replacementOptions(existing user.Order) (*perpetual.PlaceOrderOptions, error) {
+	postOnly := existing.PostOnly
+	reduceOnly := existing.ReduceOnly
+	opts := &perpetual.PlaceOrderOptions{
+		PostOnly:   &postOnly,
+		ReduceOnly: &reduceOnly,
+	}
+	if existing.TimeInForce != "" {
+		timeInForce := existing.TimeInForce
+		opts.TimeInForce = &timeInForce
+	}
+	if existing.ExpireTime > 0 {
+		expireTime := time.UnixMilli(existing.ExpireTime)
+		if expireTime.After(time.Now()) {
+			opts.ExpireTime = &expireTime
+		}
+	}
+	if existing.SelfTradeProtectionLevel != "" {
+		level := existing.SelfTradeProtectionLevel
+		opts.SelfTradeProtectionLevel = &level
+	}
+
+	if existing.BuilderFee != "" && existing.BuilderID != 0 {
+		builderFee, err := decimal.NewFromString(existing.BuilderFee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid builder fee: %w", err)
+		}
+		builderID := existing.BuilderID
+		opts.BuilderFee = &builderFee
+		opts.BuilderID = &builderID
+	}
+
+	if existing.TakeProfit != nil || existing.StopLoss != nil {
+		tpSlType := existing.TpSlType
+		if tpSlType == "" {
+			tpSlType = "ORDER"
+		}
+		opts.TpSlType = &tpSlType
+	}
+	if tp := existing.TakeProfit; tp != nil {
+		leg, err := tpSlLeg(tp.TriggerPrice, tp.TriggerPriceType, tp.Price, tp.PriceType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid take profit: %w", err)
+		}
+		opts.TakeProfit = leg
+	}
+	if sl := existing.StopLoss; sl != nil {
+		leg, err := tpSlLeg(sl.TriggerPrice, sl.TriggerPriceType, sl.Price, sl.PriceType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stop loss: %w", err)
+		}
+		opts.StopLoss = leg
+	}
+	return opts, nil
+}
+
+// tpSlLeg parses the prices of a TP/SL leg reported by the exchange.
+func tpSlLeg(triggerPrice, triggerPriceType, price, priceType string) (*perpetual.TpSlLeg, error) {
+	trigger, err := decimal.NewFromString(triggerPrice)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := decimal.NewFromString(price)
+	if err != nil {
+		return nil, err
+	}
+	return &perpetual.TpSlLeg{
+		TriggerPrice:     trigger,
+		TriggerPriceType: triggerPriceType,
+		Price:            limit,
+		PriceType:        priceType,
+	}, nil
+}
@@ -0,0 +1,469 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
+	"github.com/shopspring/decimal"
+)
+
+func TestAsOrderRejection(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantReason user.OrderStatusReason // empty when the error is not a rejection
+	}{
+		{name: "named reason", status: http.StatusBadRequest, body: `{"error":{"code":1140,"message":"Order rejected: NOT_ENOUGH_FUNDS"}}`, wantReason: user.OrderStatusReasonNotEnoughFunds},
+		{name: "reason in raw body", status: http.StatusUnprocessableEntity, body: `POST_ONLY_FAILED`, wantReason: user.OrderStatusReasonPostOnlyFailed},
+		{name: "unnamed reason", status: http.StatusConflict, body: `{"error":{"code":1,"message":"Invalid order"}}`, wantReason: user.OrderStatusReasonUnknown},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{"error":{"code":1,"message":"bad key"}}`},
+		{name: "rate limited", status: http.StatusTooManyRequests},
+		{name: "server error", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := asOrderRejection(models.NewAPIError(tt.status, http.Header{}, []byte(tt.body)), "ext-1")
+
+			var rejected *models.OrderRejectedError
+			if tt.wantReason == "" {
+				if errors.As(err, &rejected) {
+					t.Fatalf("HTTP %d was turned into a rejection", tt.status)
+				}
+				return
+			}
+			if !errors.As(err, &rejected) {
+				t.Fatalf("got %T, want *models.OrderRejectedError", err)
+			}
+			if rejected.Reason != tt.wantReason || rejected.OrderID != "ext-1" {
+				t.Errorf("got reason %s, order %s; want %s, ext-1", rejected.Reason, rejected.OrderID, tt.wantReason)
+			}
+		})
+	}
+}
+
+func signedOrderRequest() user.CreateOrderRequest {
+	return user.CreateOrderRequest{
+		ID:     "1166889461421716582054747865777410838520755143669870072976787470981175645302",
+		Market: "BTC-USD",
+		Type:   "LIMIT",
+		Side:   "BUY",
+		Qty:    "0.001",
+		Price:  "43445.1168",
+		Nonce:  "1473459052",
+		Settlement: user.Settlement{
+			Signature: user.SettlementSignature{R: "0x1", S: "0x2"},
+		},
+	}
+}
+
+func TestPlaceOrderPostRequestResolvesDuplicateOnRetry(t *testing.T) {
+	req := signedOrderRequest()
+
+	tests := []struct {
+		name      string
+		postCodes []int  // status of each POST attempt
+		lookup    string // orders returned for the external ID
+		wantID    int64  // 0 when an error is expected
+	}{
+		{
+			name:      "accepted first attempt, duplicate on retry",
+			postCodes: []int{http.StatusBadGateway, http.StatusBadRequest},
+			lookup:    `[{"id":42,"externalId":"` + req.ID + `","market":"BTC-USD","side":"BUY","status":"NEW"}]`,
+			wantID:    42,
+		},
+		{
+			name:      "rejected on retry without a live order",
+			postCodes: []int{http.StatusBadGateway, http.StatusBadRequest},
+			lookup:    `[]`,
+		},
+		{
+			name:      "rejected without a retry",
+			postCodes: []int{http.StatusBadRequest},
+			lookup:    `[{"id":42,"externalId":"` + req.ID + `","market":"BTC-USD","side":"BUY","status":"NEW"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts, lookups atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/user/order":
+					n := int(posts.Add(1))
+					code := tt.postCodes[min(n, len(tt.postCodes))-1]
+					w.WriteHeader(code)
+					fmt.Fprintf(w, `{"status":"ERROR","error":{"code":%d,"message":"Duplicate order"}}`, code)
+				case r.Method == http.MethodGet && r.URL.Path == "/user/orders/external/"+req.ID:
+					lookups.Add(1)
+					fmt.Fprintf(w, `{"status":"OK","data":%s}`, tt.lookup)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			resp, err := testClient(t, srv).PlaceOrderPostRequest(context.Background(), req)
+			if tt.wantID == 0 {
+				var rejected *models.OrderRejectedError
+				if !errors.As(err, &rejected) {
+					t.Fatalf("err = %v, want *models.OrderRejectedError", err)
+				}
+				if len(tt.postCodes) == 1 && lookups.Load() != 0 {
+					t.Error("looked up the external ID without a retry")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.ID != tt.wantID || resp.ExternalID != req.ID {
+				t.Errorf("got %+v, want id %d", resp, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestCancelOrders(t *testing.T) {
+	cancels := []struct {
+		name       string
+		method     string
+		path       string
+		query      string
+		wantFilter string // mass cancel request body
+		cancel     func(c *TradingClient) error
+	}{
+		{
+			name:   "by id",
+			method: http.MethodDelete,
+			path:   "/user/order/42",
+			cancel: func(c *TradingClient) error { return c.CancelOrder(context.Background(), 42) },
+		},
+		{
+			name:   "by external id",
+			method: http.MethodDelete,
+			path:   "/user/order",
+			query:  "externalId=ext-1",
+			cancel: func(c *TradingClient) error { return c.CancelOrderByExternalID(context.Background(), "ext-1") },
+		},
+		{
+			name:       "mass cancel",
+			method:     http.MethodPost,
+			path:       "/user/order/massCancel",
+			wantFilter: `{"markets":["BTC-USD"]}`,
+			cancel: func(c *TradingClient) error {
+				return c.MassCancel(context.Background(), []string{"BTC-USD"}, nil, nil, false)
+			},
+		},
+	}
+	responses := []struct {
+		name    string
+		code    int
+		body    string
+		wantErr bool
+	}{
+		{name: "ok", code: http.StatusOK, body: `{"status":"OK"}`},
+		{name: "empty body", code: http.StatusOK},
+		{name: "error status", code: http.StatusOK, body: `{"status":"ERROR"}`, wantErr: true},
+		{name: "rejected", code: http.StatusBadRequest, body: `{"status":"ERROR","error":{"code":1142,"message":"Order not found"}}`, wantErr: true},
+	}
+
+	for _, cc := range cancels {
+		for _, rr := range responses {
+			t.Run(cc.name+"/"+rr.name, func(t *testing.T) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Method != cc.method || r.URL.Path != cc.path || r.URL.RawQuery != cc.query {
+						t.Errorf("got %s %s?%s, want %s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery, cc.method, cc.path, cc.query)
+					}
+					if cc.wantFilter != "" {
+						body, _ := io.ReadAll(r.Body)
+						if got := strings.TrimSpace(string(body)); got != cc.wantFilter {
+							t.Errorf("body = %s, want %s", got, cc.wantFilter)
+						}
+					}
+					w.WriteHeader(rr.code)
+					fmt.Fprint(w, rr.body)
+				}))
+				defer srv.Close()
+
+				err := cc.cancel(testClient(t, srv))
+				if (err != nil) != rr.wantErr {
+					t.Fatalf("err = %v, wantErr %v", err, rr.wantErr)
+				}
+				var rejected *models.OrderRejectedError
+				if errors.As(err, &rejected) {
+					t.Errorf("cancel failure was reported as an order rejection: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestMassCancelRequiresFilter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	}))
+	defer srv.Close()
+
+	if err := testClient(t, srv).MassCancel(context.Background(), nil, nil, nil, false); err == nil {
+		t.Error("expected an error without filters")
+	}
+}
+
+func TestEditOrder(t *testing.T) {
+	existing := user.Order{
+		ID:                       42,
+		Market:                   "BTC-USD",
+		Type:                     "LIMIT",
+		Side:                     "BUY",
+		Status:                   "NEW",
+		Price:                    "50000",
+		Qty:                      "0.01",
+		TimeInForce:              "GTT",
+		TpSlType:                 "ORDER",
+		TakeProfit:               &user.TpConfig{TriggerPrice: "55000", TriggerPriceType: "LAST", Price: "55000", PriceType: "LIMIT"},
+		StopLoss:                 &user.SlConfig{TriggerPrice: "45000", TriggerPriceType: "MARK", Price: "45000", PriceType: "LIMIT"},
+		SelfTradeProtectionLevel: "CLIENT",
+		BuilderFee:               "0.0001",
+		BuilderID:                7,
+	}
+
+	tests := []struct {
+		name       string
+		externalID string
+		wantAtomic bool
+	}{
+		{name: "atomic replace by external id", externalID: "ext-1", wantAtomic: true},
+		{name: "cancel then place without external id", wantAtomic: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex, client := newFakeExchange(t)
+			order := existing
+			order.ExternalID = tt.externalID
+
+			result, err := client.EditOrder(context.Background(), order, decimal.RequireFromString("50100"), decimal.RequireFromString("0.02"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Atomic != tt.wantAtomic {
+				t.Errorf("Atomic = %t, want %t", result.Atomic, tt.wantAtomic)
+			}
+
+			wantCancels := 1
+			if tt.wantAtomic {
+				wantCancels = 0
+			}
+			if got := ex.count("DELETE /user/order/42"); got != wantCancels {
+				t.Errorf("cancelled %d times, want %d", got, wantCancels)
+			}
+
+			placed := ex.placed()
+			if len(placed) != 1 {
+				t.Fatalf("placed %d orders, want 1", len(placed))
+			}
+			req := placed[0]
+			if req.CancelID != tt.externalID {
+				t.Errorf("cancelId = %q, want %q", req.CancelID, tt.externalID)
+			}
+			if req.Price != "50100" || req.Qty != "0.02" || req.Side != "BUY" || req.TimeInForce != "GTT" {
+				t.Errorf("got %s %s @ %s %s, want BUY 0.02 @ 50100 GTT", req.Side, req.Qty, req.Price, req.TimeInForce)
+			}
+			if req.SelfTradeProtectionLevel != "CLIENT" {
+				t.Errorf("selfTradeProtectionLevel = %q, want CLIENT", req.SelfTradeProtectionLevel)
+			}
+			if req.BuilderFee != "0.0001" || req.BuilderID != 7 {
+				t.Errorf("builder fee %q, id %d; want 0.0001, 7", req.BuilderFee, req.BuilderID)
+			}
+			if req.TpSlType != "ORDER" || req.TakeProfit == nil || req.StopLoss == nil {
+				t.Fatalf("TP/SL legs were not copied: type %q, tp %v, sl %v", req.TpSlType, req.TakeProfit, req.StopLoss)
+			}
+			if req.TakeProfit.TriggerPrice != "55000" || req.TakeProfit.TriggerPriceType != "LAST" || req.TakeProfit.Settlement == nil {
+				t.Errorf("take profit = %+v", req.TakeProfit)
+			}
+			if req.StopLoss.TriggerPrice != "45000" || req.StopLoss.TriggerPriceType != "MARK" || req.StopLoss.Settlement == nil {
+				t.Errorf("stop loss = %+v", req.StopLoss)
+			}
+		})
+	}
+}
+
+func TestEditOrderFailedReplacementAfterCancel(t *testing.T) {
+	ex, client := newFakeExchange(t)
+	order := user.Order{ID: 42, Market: "BTC-USD", Type: "LIMIT", Side: "BUY", Status: "NEW"}
+
+	// below the minimum order size, so the replacement is rejected before it is sent
+	_, err := client.EditOrder(context.Background(), order, decimal.RequireFromString("50000"), decimal.RequireFromString("0.00001"))
+	if err == nil || !strings.Contains(err.Error(), "was cancelled") {
+		t.Fatalf("got %v, want an error saying the order was cancelled", err)
+	}
+	if got := ex.count("DELETE /user/order/42"); got != 1 {
+		t.Errorf("cancelled %d times, want 1", got)
+	}
+	if got := len(ex.placed()); got != 0 {
+		t.Errorf("placed %d orders, want 0", got)
+	}
+}
+
+func TestOrderMarketState(t *testing.T) {
+	markPrice := decimal.RequireFromString("50000")
+	openOrders := 3
+	supplied := &perpetual.PlaceOrderOptions{MarkPrice: &markPrice, OpenOrders: &openOrders}
+	qty := decimal.RequireFromString("0.01")
+	price := decimal.RequireFromString("50000")
+	hourly := perpetual.TwapParams{Duration: time.Hour, Frequency: 30 * time.Second}
+	stopBuy := perpetual.TriggerParams{
+		TriggerPrice:       decimal.RequireFromString("51000"),
+		TriggerPriceType:   "LAST",
+		Direction:          "UP",
+		ExecutionPriceType: "LIMIT",
+	}
+
+	tests := []struct {
+		name       string
+		checks     bool
+		place      func(*TradingClient) error
+		wantStats  int // GET .../stats requests
+		wantOrders int // GET /user/orders requests
+	}{
+		{
+			name: "limit, checks disabled",
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceOrder(context.Background(), "BTC-USD", qty, price, "BUY", nil)
+				return err
+			},
+		},
+		{
+			name:   "limit, checks enabled",
+			checks: true,
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceOrder(context.Background(), "BTC-USD", qty, price, "BUY", nil)
+				return err
+			},
+			wantStats:  1,
+			wantOrders: 1,
+		},
+		{
+			name:   "limit, state supplied",
+			checks: true,
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceOrder(context.Background(), "BTC-USD", qty, price, "BUY", supplied)
+				return err
+			},
+		},
+		{
+			name:   "conditional, checks enabled",
+			checks: true,
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceConditionalOrder(context.Background(), "BTC-USD", qty, price, "BUY", stopBuy, nil)
+				return err
+			},
+			wantOrders: 1,
+		},
+		{
+			name:   "conditional, state supplied",
+			checks: true,
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceConditionalOrder(context.Background(), "BTC-USD", qty, price, "BUY", stopBuy, supplied)
+				return err
+			},
+		},
+		{
+			name:   "twap, checks enabled",
+			checks: true,
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceTwapOrder(context.Background(), "BTC-USD", qty, price, "BUY", hourly, nil)
+				return err
+			},
+			wantStats:  1,
+			wantOrders: 1,
+		},
+		{
+			name:   "twap, state supplied",
+			checks: true,
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceTwapOrder(context.Background(), "BTC-USD", qty, price, "BUY", hourly, supplied)
+				return err
+			},
+		},
+		{
+			name: "market, checks disabled",
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceMarketOrder(context.Background(), "BTC-USD", qty, "BUY", decimal.RequireFromString("0.01"))
+				return err
+			},
+		},
+		{
+			name:   "market, checks enabled",
+			checks: true,
+			place: func(c *TradingClient) error {
+				_, err := c.PlaceMarketOrder(context.Background(), "BTC-USD", qty, "BUY", decimal.RequireFromString("0.01"))
+				return err
+			},
+			wantStats: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex, client := newFakeExchange(t)
+			client.SetMarketStateChecks(tt.checks)
+
+			if err := tt.place(client); err != nil {
+				t.Fatal(err)
+			}
+			if got := ex.count("GET /info/markets/BTC-USD/stats"); got != tt.wantStats {
+				t.Errorf("fetched market stats %d times, want %d", got, tt.wantStats)
+			}
+			if got := ex.count("GET /user/orders"); got != tt.wantOrders {
+				t.Errorf("fetched open orders %d times, want %d", got, tt.wantOrders)
+			}
+			if got := len(ex.placed()); got != 1 {
+				t.Errorf("placed %d orders, want 1", got)
+			}
+		})
+	}
+}
+
+func TestOrderMarketStateRejects(t *testing.T) {
+	qty := decimal.RequireFromString("0.01")
+
+	tests := []struct {
+		name       string
+		price      string
+		openOrders int
+		want       error
+	}{
+		{name: "price above the cap", price: "53000", want: perpetual.ErrPriceTooFarFromMark},
+		{name: "too many open orders", price: "50000", openOrders: 200, want: perpetual.ErrTooManyOrders},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex, client := newFakeExchange(t)
+			ex.openOrders = tt.openOrders
+			client.SetMarketStateChecks(true)
+
+			_, err := client.PlaceOrder(context.Background(), "BTC-USD", qty, decimal.RequireFromString(tt.price), "BUY", nil)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if got := len(ex.placed()); got != 0 {
+				t.Errorf("placed %d orders, want 0", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients/stream"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+)
+
+// SubscribeToAccountUpdates opens the private account stream authenticated with the account's API key.
+// Use it instead of polling GetOpenOrders/GetPositions to receive order, trade, position and balance updates.
+func (c *TradingClient) SubscribeToAccountUpdates(ctx context.Context) (*stream.Subscription[user.AccountUpdate], error) {
+	streamClient := c.Stream()
+	if streamClient == nil {
+		return nil, fmt.Errorf("streaming is not enabled on this client")
+	}
+	return streamClient.SubscribeToAccountUpdates(ctx, c.account.APIKey)
+}
@@ -0,0 +1,65 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
+	"github.com/shopspring/decimal"
+)
+
+// GetAccounts retrieves the sub-accounts of the wallet that owns the client's account.
+func (c *TradingClient) GetAccounts(ctx context.Context) ([]user.SubAccount, error) {
+	endpoint := "/user/accounts"
+
+	var response struct {
+		Status string            `json:"status"`
+		Data   []user.SubAccount `json:"data"`
+	}
+
+	if err := c.httpClient.Get(ctx, endpoint, &response); err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+// Transfer moves amount of collateral from the client's account to the sub-account with vault toVault.
+// The receiver's Stark public key is looked up with GetAccounts, so toVault must belong to the same
+// wallet; use TransferToKey for other accounts. The transfer can be tracked with GetAssetOperations
+// using the TRANSFER type.
+func (c *TradingClient) Transfer(ctx context.Context, toVault int, amount decimal.Decimal) error {
+	accounts, err := c.GetAccounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		if account.L2Vault == toVault {
+			return c.TransferToKey(ctx, toVault, account.L2Key, amount)
+		}
+	}
+	return fmt.Errorf("no sub-account with vault %d", toVault)
+}
+
+// TransferToKey is Transfer to a vault whose Stark public key (its l2Key) is known, without looking it up.
+func (c *TradingClient) TransferToKey(ctx context.Context, toVault int, toL2Key string, amount decimal.Decimal) error {
+	if c.account == nil {
+		return fmt.Errorf("stark account is not set")
+	}
+
+	req, err := perpetual.CreateTransfer(c.account.WithContext(ctx), c.config, toVault, toL2Key, amount)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Status string `json:"status"`
+	}
+
+	if err := c.httpClient.Post(ctx, "/user/transfer/onchain", req, &response); err != nil {
+		return fmt.Errorf("failed to transfer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/shopspring/decimal"
+)
+
+func TestTransfer(t *testing.T) {
+	const receiverKey = "0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466"
+
+	tests := []struct {
+		name    string
+		toVault int
+		wantErr bool
+	}{
+		{name: "sub-account of the wallet", toVault: 10003},
+		{name: "unknown vault", toVault: 99999, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var transfers []user.TransferRequest
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/user/accounts":
+					fmt.Fprintf(w, `{"status":"OK","data":[
+						{"id":1,"accountIndex":0,"l2Key":"0x1","l2Vault":"10002"},
+						{"id":2,"accountIndex":1,"l2Key":%q,"l2Vault":10003}]}`, receiverKey)
+				case r.Method == http.MethodPost && r.URL.Path == "/user/transfer/onchain":
+					var req user.TransferRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					mu.Lock()
+					transfers = append(transfers, req)
+					mu.Unlock()
+					fmt.Fprint(w, `{"status":"OK"}`)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			t.Cleanup(srv.Close)
+
+			err := testClient(t, srv).Transfer(context.Background(), tt.toVault, decimal.RequireFromString("12.5"))
+			mu.Lock()
+			defer mu.Unlock()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(transfers) != 0 {
+					t.Errorf("sent %d transfers, want 0", len(transfers))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(transfers) != 1 {
+				t.Fatalf("sent %d transfers, want 1", len(transfers))
+			}
+			req := transfers[0]
+			if req.FromVault != 10002 || req.ToVault != tt.toVault || !req.Amount.Equal(decimal.RequireFromString("12.5")) {
+				t.Errorf("got transfer of %s from %d to %d, want 12.5 from 10002 to %d", req.Amount, req.FromVault, req.ToVault, tt.toVault)
+			}
+			if req.Settlement.ReceiverPublicKey != receiverKey || req.Settlement.ReceiverPositionID != tt.toVault {
+				t.Errorf("settlement receiver = %s at %d, want %s at %d",
+					req.Settlement.ReceiverPublicKey, req.Settlement.ReceiverPositionID, receiverKey, tt.toVault)
+			}
+		})
+	}
+}
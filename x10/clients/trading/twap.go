@@ -0,0 +1,147 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
+	"github.com/shopspring/decimal"
+)
+
+// TwapOrder is a handle to a submitted TWAP order, used to follow its execution.
+type TwapOrder struct {
+	ID         int64
+	ExternalID string
+	Market     string
+	Side       string
+	Qty        decimal.Decimal
+
+	client *TradingClient
+}
+
+// TwapProgress is a point-in-time view of a TWAP order's execution.
+type TwapProgress struct {
+	Order        user.Order
+	FilledQty    decimal.Decimal
+	AveragePrice decimal.Decimal
+	Progress     decimal.Decimal // FilledQty / Qty, between 0 and 1
+	Done         bool            // the order reached a final status
+}
+
+// PlaceTwapOrder creates and submits a TWAP order executed by the exchange in slices over twap.Duration.
+// price is the protective worst price for every slice. The returned handle polls the order's progress.
+func (c *TradingClient) PlaceTwapOrder(ctx context.Context, market string, amountOfSynthetic decimal.Decimal, price decimal.Decimal, side string, twap perpetual.TwapParams, opts *perpetual.PlaceOrderOptions) (*TwapOrder, error) {
+	if c.account == nil {
+		return nil, fmt.Errorf("stark account is not set")
+	}
+
+	mkt, err := c.FetchMarketData(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err = c.withTradingFee(ctx, market, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateOptions(ctx, market, opts); err != nil {
+		return nil, err
+	}
+
+	opts, err = c.withMarketState(ctx, market, "TWAP", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := perpetual.CreateTwapOrder(c.account.WithContext(ctx), mkt, amountOfSynthetic, price, side, twap, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PlaceOrderPostRequest(ctx, *req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwapOrder{
+		ID:         resp.ID,
+		ExternalID: resp.ExternalID,
+		Market:     market,
+		Side:       side,
+		Qty:        amountOfSynthetic,
+		client:     c,
+	}, nil
+}
+
+// Progress fetches the current state of the TWAP order via GetOrderByID.
+func (t *TwapOrder) Progress(ctx context.Context) (*TwapProgress, error) {
+	order, err := t.client.GetOrderByID(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &TwapProgress{
+		Order:        *order,
+		FilledQty:    decimal.Zero,
+		AveragePrice: decimal.Zero,
+		Progress:     decimal.Zero,
+		Done:         isFinalOrderStatus(order.Status),
+	}
+	if order.FilledQty != "" {
+		if progress.FilledQty, err = decimal.NewFromString(order.FilledQty); err != nil {
+			return nil, fmt.Errorf("invalid filled qty %q: %w", order.FilledQty, err)
+		}
+	}
+	if order.AveragePrice != "" {
+		if progress.AveragePrice, err = decimal.NewFromString(order.AveragePrice); err != nil {
+			return nil, fmt.Errorf("invalid average price %q: %w", order.AveragePrice, err)
+		}
+	}
+	if t.Qty.IsPositive() {
+		progress.Progress = progress.FilledQty.Div(t.Qty)
+	}
+	return progress, nil
+}
+
+// Wait polls the order every interval until it reaches a final status or ctx is done,
+// and returns the last observed progress.
+func (t *TwapOrder) Wait(ctx context.Context, interval time.Duration) (*TwapProgress, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		progress, err := t.Progress(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if progress.Done {
+			return progress, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return progress, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cancel cancels the remaining, unexecuted part of the TWAP order.
+func (t *TwapOrder) Cancel(ctx context.Context) error {
+	return t.client.CancelOrder(ctx, t.ID)
+}
+
+// isFinalOrderStatus reports whether an order with status can no longer change.
+func isFinalOrderStatus(status string) bool {
+	switch status {
+	case "FILLED", "CANCELLED", "REJECTED", "EXPIRED":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/perpetual"
+	"github.com/shopspring/decimal"
+)
+
+// Withdraw requests a slow (on-chain) withdrawal of amount collateral to the Ethereum address ethAddress.
+// It returns the ID of the created asset operation, which matches AssetOperation.ID in GetAssetOperations
+// (type WITHDRAWAL).
+func (c *TradingClient) Withdraw(ctx context.Context, amount decimal.Decimal, ethAddress string) (string, error) {
+	if c.account == nil {
+		return "", fmt.Errorf("stark account is not set")
+	}
+
+	req, err := perpetual.CreateWithdrawal(c.account.WithContext(ctx), c.config, amount, ethAddress, "")
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+
+	if err := c.httpClient.Post(ctx, "/user/withdrawal/onchain", req, &response); err != nil {
+		return "", fmt.Errorf("failed to withdraw: %w", err)
+	}
+
+	id := strings.Trim(string(response.Data), `"`)
+	if id == "" || id == "null" {
+		return "", fmt.Errorf("failed to withdraw: response has no asset operation ID")
+	}
+	return id, nil
+}
@@ -1,6 +1,7 @@
 package x10
 
 import (
+	"log/slog"
 	"os"
 
 	"github.com/joho/godotenv"
@@ -11,6 +12,30 @@ type Config struct {
 	APIBaseURL  string
 	StreamURL   string
 	Environment string
+
+	// OnboardingURL is the base URL of the account onboarding endpoints, used by the onboarding UserClient.
+	OnboardingURL string
+	// SigningDomain is the EIP-712 domain name of onboarding signatures. Accounts created before the
+	// exchange moved to extended.exchange were derived with "x10.exchange".
+	SigningDomain string
+
+	// CollateralAssetOnChainID is the Stark asset ID of the collateral moved by transfers and withdrawals.
+	CollateralAssetOnChainID string
+	// CollateralDecimals is the number of decimals of the collateral's on-chain amounts.
+	CollateralDecimals int32
+
+	// Logger receives the SDK's diagnostics: requests and responses at Debug, retries and
+	// reconnects at Info, failed requests at Warn. API keys, signatures and keys are redacted.
+	// Nil discards all output.
+	Logger *slog.Logger
+}
+
+// SlogLogger returns the configured logger, or a logger that discards everything when none is set.
+func (c *Config) SlogLogger() *slog.Logger {
+	if c == nil || c.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return c.Logger
 }
 
 // LoadFromEnv loads configuration from environment variables
@@ -31,6 +56,12 @@ func Testnet() *Config {
 		APIBaseURL:  "https://api.starknet.sepolia.extended.exchange/api/v1",
 		StreamURL:   "wss://starknet.sepolia.extended.exchange/stream.extended.exchange/v1",
 		Environment: "testnet",
+
+		OnboardingURL: "https://api.starknet.sepolia.extended.exchange",
+		SigningDomain: "starknet.sepolia.extended.exchange",
+
+		CollateralAssetOnChainID: "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+		CollateralDecimals:       6,
 	}
 }
 
@@ -40,6 +71,12 @@ func Mainnet() *Config {
 		APIBaseURL:  "https://api.starknet.extended.exchange/api/v1",
 		StreamURL:   "wss://api.starknet.extended.exchange/stream.extended.exchange/v1",
 		Environment: "mainnet",
+
+		OnboardingURL: "https://api.starknet.extended.exchange",
+		SigningDomain: "extended.exchange",
+
+		CollateralAssetOnChainID: "0x2893294412a4c8f915f75892b395ebbf6859ec246ec365c3b1f56f47c3a0a5d",
+		CollateralDecimals:       6,
 	}
 }
 
@@ -1,11 +1,32 @@
 package models
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+)
+
+// Sentinel errors matched by errors.Is against any error returned by the clients.
+var (
+	ErrRateLimited   = errors.New("rate limited")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrNotFound      = errors.New("not found")
+	ErrOrderRejected = errors.New("order rejected")
+)
+
+// X10Error is an error reported by the exchange (or by the SDK for invalid configuration).
+// For API errors Code and Message come from the response envelope's error object and
+// HTTPStatus holds the HTTP status code; Details holds the raw body when it has no envelope.
 type X10Error struct {
-	Code    int    `json:"code,omitempty"`
-	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
+	Code       int    `json:"code,omitempty"`
+	Message    string `json:"message"`
+	Details    string `json:"details,omitempty"`
+	HTTPStatus int    `json:"-"`
 }
 
 func (e *X10Error) Error() string {
@@ -14,3 +35,112 @@ func (e *X10Error) Error() string {
 	}
 	return fmt.Sprintf("X10Error [%d]: %s", e.Code, e.Message)
 }
+
+// Is maps the HTTP status to the sentinel errors, e.g. errors.Is(err, models.ErrNotFound).
+func (e *X10Error) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.HTTPStatus == http.StatusTooManyRequests
+	case ErrUnauthorized:
+		return e.HTTPStatus == http.StatusUnauthorized
+	case ErrNotFound:
+		return e.HTTPStatus == http.StatusNotFound
+	}
+	return false
+}
+
+// RateLimitError is returned for HTTP 429 responses (RateLimitException in the Python SDK).
+type RateLimitError struct {
+	*X10Error
+	// RetryAfter is the delay requested by the Retry-After header, or zero when it was absent
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.X10Error
+}
+
+// NotAuthorizedError is returned for HTTP 401 responses (NotAuthorizedException in the Python SDK).
+type NotAuthorizedError struct {
+	*X10Error
+}
+
+func (e *NotAuthorizedError) Unwrap() error {
+	return e.X10Error
+}
+
+// NotFoundError is returned for HTTP 404 responses.
+type NotFoundError struct {
+	*X10Error
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.X10Error
+}
+
+// OrderRejectedError is returned when the exchange refuses to place or edit an order.
+// Code and Message carry the exchange's error; Reason is the rejection reason named in it, if any.
+type OrderRejectedError struct {
+	*X10Error
+	OrderID string                 // the external ID of the rejected order, if known
+	Reason  user.OrderStatusReason // OrderStatusReasonUnknown when the exchange did not name one
+}
+
+func (e *OrderRejectedError) Unwrap() error {
+	return e.X10Error
+}
+
+func (e *OrderRejectedError) Is(target error) bool {
+	return target == ErrOrderRejected || e.X10Error.Is(target)
+}
+
+// NewAPIError builds the typed error for a failed API response from its HTTP status, headers and body.
+// The body is decoded as the exchange envelope {"status": "ERROR", "error": {"code": ..., "message": ...}};
+// when it is not an envelope, the raw body is kept in Details.
+func NewAPIError(httpStatus int, header http.Header, body []byte) error {
+	apiErr := &X10Error{HTTPStatus: httpStatus}
+
+	var envelope struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil {
+		apiErr.Code = envelope.Error.Code
+		apiErr.Message = envelope.Error.Message
+	} else {
+		apiErr.Code = httpStatus
+		apiErr.Message = http.StatusText(httpStatus)
+		apiErr.Details = string(body)
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("request failed with status: %d", httpStatus)
+	}
+
+	switch httpStatus {
+	case http.StatusTooManyRequests:
+		return &RateLimitError{X10Error: apiErr, RetryAfter: parseRetryAfter(header.Get("Retry-After"))}
+	case http.StatusUnauthorized:
+		return &NotAuthorizedError{X10Error: apiErr}
+	case http.StatusNotFound:
+		return &NotFoundError{X10Error: apiErr}
+	}
+	return apiErr
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
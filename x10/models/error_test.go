@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewAPIError(t *testing.T) {
+	t.Run("envelope", func(t *testing.T) {
+		err := NewAPIError(http.StatusBadRequest, nil, []byte(`{"status":"ERROR","error":{"code":1140,"message":"Order rejected: NOT_ENOUGH_FUNDS"}}`))
+		var apiErr *X10Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got %T, want *X10Error", err)
+		}
+		if apiErr.Code != 1140 || apiErr.Message != "Order rejected: NOT_ENOUGH_FUNDS" || apiErr.Details != "" {
+			t.Errorf("got %+v", apiErr)
+		}
+		if apiErr.HTTPStatus != http.StatusBadRequest {
+			t.Errorf("HTTPStatus = %d, want 400", apiErr.HTTPStatus)
+		}
+	})
+
+	t.Run("raw body", func(t *testing.T) {
+		err := NewAPIError(http.StatusBadGateway, nil, []byte("<html>bad gateway</html>"))
+		var apiErr *X10Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got %T, want *X10Error", err)
+		}
+		if apiErr.Code != http.StatusBadGateway || apiErr.Message != "Bad Gateway" || apiErr.Details != "<html>bad gateway</html>" {
+			t.Errorf("got %+v", apiErr)
+		}
+	})
+
+	t.Run("empty message", func(t *testing.T) {
+		err := NewAPIError(http.StatusConflict, nil, []byte(`{"error":{"code":7}}`))
+		if got, want := err.Error(), "X10Error [7]: request failed with status: 409"; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		header := http.Header{"Retry-After": {"3"}}
+		err := NewAPIError(http.StatusTooManyRequests, header, nil)
+		var rateErr *RateLimitError
+		if !errors.As(err, &rateErr) {
+			t.Fatalf("got %T, want *RateLimitError", err)
+		}
+		if rateErr.RetryAfter != 3*time.Second {
+			t.Errorf("RetryAfter = %s, want 3s", rateErr.RetryAfter)
+		}
+		if !errors.Is(err, ErrRateLimited) {
+			t.Error("errors.Is(err, ErrRateLimited) = false")
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		err := NewAPIError(http.StatusUnauthorized, nil, nil)
+		var authErr *NotAuthorizedError
+		if !errors.As(err, &authErr) || !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("got %T, want *NotAuthorizedError", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := NewAPIError(http.StatusNotFound, nil, nil)
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) || !errors.Is(err, ErrNotFound) {
+			t.Fatalf("got %T, want *NotFoundError", err)
+		}
+		if errors.Is(err, ErrRateLimited) {
+			t.Error("not found matched ErrRateLimited")
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{name: "empty", value: "", min: 0, max: 0},
+		{name: "seconds", value: "120", min: 120 * time.Second, max: 120 * time.Second},
+		{name: "zero seconds", value: "0", min: 0, max: 0},
+		{name: "negative seconds", value: "-5", min: 0, max: 0},
+		{name: "garbage", value: "soon", min: 0, max: 0},
+		{name: "http date", value: time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat), min: 28 * time.Second, max: 30 * time.Second},
+		{name: "past http date", value: "Wed, 21 Oct 2015 07:28:00 GMT", min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseRetryAfter(tt.value)
+			if got < tt.min || got > tt.max {
+				t.Errorf("parseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestOrderRejectedErrorIs(t *testing.T) {
+	err := error(&OrderRejectedError{X10Error: &X10Error{HTTPStatus: http.StatusBadRequest}})
+	if !errors.Is(err, ErrOrderRejected) {
+		t.Error("errors.Is(err, ErrOrderRejected) = false")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("rejection matched ErrNotFound")
+	}
+}
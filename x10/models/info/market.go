@@ -21,6 +21,7 @@ func (r *RiskFactorConfig) MaxLeverage() decimal.Decimal {
 	}
 	return decimal.NewFromInt(1).Div(r.RiskFactor).Round(2)
 }
+
 type TradingConfig struct {
 	MinOrderSize        decimal.Decimal    `json:"minOrderSize"`
 	MinOrderSizeChange  decimal.Decimal    `json:"minOrderSizeChange"`
@@ -34,6 +35,33 @@ type TradingConfig struct {
 	LimitPriceFloor     decimal.Decimal    `json:"limitPriceFloor"`
 	RiskFactorConfig    []RiskFactorConfig `json:"riskFactorConfig"`
 }
+
+// MaxLeverageForValue returns the highest leverage allowed for a position worth positionValue:
+// the MaxLeverage of the first risk factor tier whose UpperBound covers the value, capped by MaxLeverage.
+// A value above every tier gets 0, as no leverage is allowed. Without tiers MaxLeverage is returned.
+func (c *TradingConfig) MaxLeverageForValue(positionValue decimal.Decimal) decimal.Decimal {
+	if len(c.RiskFactorConfig) == 0 {
+		return c.MaxLeverage
+	}
+
+	var tier *RiskFactorConfig
+	for i := range c.RiskFactorConfig {
+		if positionValue.LessThanOrEqual(c.RiskFactorConfig[i].UpperBound) {
+			tier = &c.RiskFactorConfig[i]
+			break
+		}
+	}
+	if tier == nil {
+		return decimal.Zero
+	}
+
+	maxLeverage := tier.MaxLeverage()
+	if c.MaxLeverage.IsPositive() && c.MaxLeverage.LessThan(maxLeverage) {
+		return c.MaxLeverage
+	}
+	return maxLeverage
+}
+
 type Market struct {
 	Name                     string        `json:"name"`
 	AssetName                string        `json:"assetName"`
@@ -0,0 +1,41 @@
+package info
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMaxLeverageForValue(t *testing.T) {
+	cfg := TradingConfig{
+		MaxLeverage: decimal.RequireFromString("40"),
+		RiskFactorConfig: []RiskFactorConfig{
+			{UpperBound: decimal.RequireFromString("400000"), RiskFactor: decimal.RequireFromString("0.02")},
+			{UpperBound: decimal.RequireFromString("800000"), RiskFactor: decimal.RequireFromString("0.04")},
+			{UpperBound: decimal.RequireFromString("1200000"), RiskFactor: decimal.RequireFromString("0.06")},
+		},
+	}
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"0", "40"}, // capped by MaxLeverage
+		{"400000", "40"},
+		{"400001", "25"},
+		{"800000", "25"},
+		{"1000000", "16.67"},
+		{"1200000", "16.67"},
+		{"1200001", "0"},
+	}
+	for _, tt := range tests {
+		got := cfg.MaxLeverageForValue(decimal.RequireFromString(tt.value))
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("MaxLeverageForValue(%s) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	if got := (&TradingConfig{MaxLeverage: decimal.RequireFromString("10")}).MaxLeverageForValue(decimal.RequireFromString("1e9")); !got.Equal(decimal.RequireFromString("10")) {
+		t.Errorf("without tiers got %s, want MaxLeverage", got)
+	}
+}
@@ -1,6 +1,11 @@
 package info
 
-import "github.com/shopspring/decimal"
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
 
 // OrderBookEntry represents a single entry in the order book
 type OrderBookEntry struct {
@@ -14,3 +19,170 @@ type OrderBook struct {
 	Bid    []OrderBookEntry `json:"bid"`
 	Ask    []OrderBookEntry `json:"ask"`
 }
+
+// UnmarshalJSON accepts both the REST field names (qty, price) and the stream short names (q, p).
+func (e *OrderBookEntry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Qty   *decimal.Decimal `json:"qty"`
+		Q     *decimal.Decimal `json:"q"`
+		Price *decimal.Decimal `json:"price"`
+		P     *decimal.Decimal `json:"p"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*e = OrderBookEntry{}
+	if raw.Qty != nil {
+		e.Qty = *raw.Qty
+	} else if raw.Q != nil {
+		e.Qty = *raw.Q
+	}
+	if raw.Price != nil {
+		e.Price = *raw.Price
+	} else if raw.P != nil {
+		e.Price = *raw.P
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts both the REST field names (market, bid, ask) and the stream short names (m, b, a).
+func (o *OrderBook) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Market *string           `json:"market"`
+		M      *string           `json:"m"`
+		Bid    *[]OrderBookEntry `json:"bid"`
+		B      *[]OrderBookEntry `json:"b"`
+		Ask    *[]OrderBookEntry `json:"ask"`
+		A      *[]OrderBookEntry `json:"a"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*o = OrderBook{}
+	if raw.Market != nil {
+		o.Market = *raw.Market
+	} else if raw.M != nil {
+		o.Market = *raw.M
+	}
+	if raw.Bid != nil {
+		o.Bid = *raw.Bid
+	} else if raw.B != nil {
+		o.Bid = *raw.B
+	}
+	if raw.Ask != nil {
+		o.Ask = *raw.Ask
+	} else if raw.A != nil {
+		o.Ask = *raw.A
+	}
+	return nil
+}
+
+// PriceImpact describes the result of sweeping the book with a hypothetical market order.
+type PriceImpact struct {
+	AveragePrice decimal.Decimal // volume-weighted average fill price
+	WorstPrice   decimal.Decimal // price of the last (deepest) level touched
+	Qty          decimal.Decimal // synthetic quantity filled
+	Notional     decimal.Decimal // collateral consumed (sum of qty * price)
+}
+
+// PriceImpactQty computes the fill of a market order for qty of the synthetic asset.
+// side is the taker side: "BUY" walks the asks, "SELL" walks the bids.
+// Returns nil for a non-positive qty, an unknown side, or when the book lacks liquidity to fill it entirely.
+func (o *OrderBook) PriceImpactQty(qty decimal.Decimal, side string) *PriceImpact {
+	if !qty.IsPositive() {
+		return nil
+	}
+	levels := o.takerLevels(side)
+	if len(levels) == 0 {
+		return nil
+	}
+
+	remaining := qty
+	impact := PriceImpact{Qty: decimal.Zero, Notional: decimal.Zero}
+	for _, level := range levels {
+		if !remaining.IsPositive() {
+			break
+		}
+		if !level.Qty.IsPositive() {
+			continue
+		}
+		take := decimal.Min(remaining, level.Qty)
+		impact.Qty = impact.Qty.Add(take)
+		impact.Notional = impact.Notional.Add(take.Mul(level.Price))
+		impact.WorstPrice = level.Price
+		remaining = remaining.Sub(take)
+	}
+
+	if remaining.IsPositive() {
+		return nil
+	}
+	impact.AveragePrice = impact.Notional.Div(impact.Qty)
+	return &impact
+}
+
+// PriceImpactNotional computes the fill of a market order spending a collateral budget of notional.
+// side is the taker side: "BUY" walks the asks, "SELL" walks the bids.
+// Returns nil for a non-positive notional, an unknown side, or when the book lacks liquidity to absorb it entirely.
+func (o *OrderBook) PriceImpactNotional(notional decimal.Decimal, side string) *PriceImpact {
+	if !notional.IsPositive() {
+		return nil
+	}
+	levels := o.takerLevels(side)
+	if len(levels) == 0 {
+		return nil
+	}
+
+	remaining := notional
+	impact := PriceImpact{Qty: decimal.Zero, Notional: decimal.Zero}
+	for _, level := range levels {
+		if !remaining.IsPositive() {
+			break
+		}
+		if !level.Qty.IsPositive() || !level.Price.IsPositive() {
+			continue
+		}
+		take, spent := level.Qty, level.Qty.Mul(level.Price)
+		if spent.GreaterThan(remaining) {
+			// The budget runs out inside this level; spend it exactly to avoid division rounding leftovers.
+			take, spent = remaining.Div(level.Price), remaining
+		}
+		impact.Qty = impact.Qty.Add(take)
+		impact.Notional = impact.Notional.Add(spent)
+		impact.WorstPrice = level.Price
+		remaining = remaining.Sub(spent)
+	}
+
+	if remaining.IsPositive() {
+		return nil
+	}
+	impact.AveragePrice = impact.Notional.Div(impact.Qty)
+	return &impact
+}
+
+// TakerPrice returns the best price a taker order on side would trade at:
+// the lowest ask for "BUY", the highest bid for "SELL". ok is false when that side is empty.
+func (o *OrderBook) TakerPrice(side string) (price decimal.Decimal, ok bool) {
+	levels := o.takerLevels(side)
+	for _, level := range levels {
+		if level.Qty.IsPositive() {
+			return level.Price, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// takerLevels returns the side a taker order consumes, sorted best price first.
+func (o *OrderBook) takerLevels(side string) []OrderBookEntry {
+	var levels []OrderBookEntry
+	switch side {
+	case "BUY":
+		levels = append(levels, o.Ask...)
+		sort.SliceStable(levels, func(i, j int) bool { return levels[i].Price.LessThan(levels[j].Price) })
+	case "SELL":
+		levels = append(levels, o.Bid...)
+		sort.SliceStable(levels, func(i, j int) bool { return levels[i].Price.GreaterThan(levels[j].Price) })
+	}
+	return levels
+}
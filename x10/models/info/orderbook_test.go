@@ -0,0 +1,140 @@
+package info
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func d(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+// testOrderBook is the book of python_sdk/tests/perpetual/test_orderbook_price_impact.py.
+func testOrderBook() *OrderBook {
+	return &OrderBook{
+		Market: "dummy-market",
+		Bid: []OrderBookEntry{
+			{Price: d("100"), Qty: d("1")},
+			{Price: d("99"), Qty: d("2")},
+			{Price: d("98"), Qty: d("1")},
+		},
+		Ask: []OrderBookEntry{
+			{Price: d("101"), Qty: d("1")},
+			{Price: d("102"), Qty: d("2")},
+			{Price: d("103"), Qty: d("1")},
+		},
+	}
+}
+
+func TestPriceImpactNotional(t *testing.T) {
+	tests := []struct {
+		name      string
+		notional  decimal.Decimal
+		side      string
+		wantQty   decimal.Decimal // ignored when wantNil
+		wantWorst decimal.Decimal
+		wantNil   bool
+	}{
+		{name: "partial buy", notional: d("105"), side: "BUY", wantQty: d("1").Add(d("4").Div(d("102"))), wantWorst: d("102")},
+		{name: "partial sell", notional: d("110"), side: "SELL", wantQty: d("1").Add(d("10").Div(d("99"))), wantWorst: d("99")},
+		{name: "total match sell", notional: d("199"), side: "SELL", wantQty: d("2"), wantWorst: d("99")},
+		{name: "total match buy", notional: d("408"), side: "BUY", wantQty: d("4"), wantWorst: d("103")},
+		{name: "insufficient liquidity bid", notional: d("1000"), side: "SELL", wantNil: true},
+		{name: "insufficient liquidity ask", notional: d("1000"), side: "BUY", wantNil: true},
+		{name: "negative notional", notional: d("-10"), side: "SELL", wantNil: true},
+		{name: "zero notional", notional: d("0"), side: "BUY", wantNil: true},
+		{name: "invalid side", notional: d("100"), side: "invalid", wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testOrderBook().PriceImpactNotional(tt.notional, tt.side)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("got %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("got nil")
+			}
+			if !got.Qty.Equal(tt.wantQty) {
+				t.Errorf("Qty = %s, want %s", got.Qty, tt.wantQty)
+			}
+			if !got.Notional.Equal(tt.notional) {
+				t.Errorf("Notional = %s, want %s", got.Notional, tt.notional)
+			}
+			if want := tt.notional.Div(tt.wantQty); !got.AveragePrice.Equal(want) {
+				t.Errorf("AveragePrice = %s, want %s", got.AveragePrice, want)
+			}
+			if !got.WorstPrice.Equal(tt.wantWorst) {
+				t.Errorf("WorstPrice = %s, want %s", got.WorstPrice, tt.wantWorst)
+			}
+		})
+	}
+}
+
+func TestPriceImpactQty(t *testing.T) {
+	tests := []struct {
+		name      string
+		qty       decimal.Decimal
+		side      string
+		wantAvg   decimal.Decimal // ignored when wantNil
+		wantWorst decimal.Decimal
+		wantNil   bool
+	}{
+		{name: "partial buy", qty: d("2"), side: "BUY", wantAvg: d("101.5"), wantWorst: d("102")},
+		{name: "partial sell", qty: d("2"), side: "SELL", wantAvg: d("99.5"), wantWorst: d("99")},
+		{name: "partial last level", qty: d("1.5"), side: "BUY", wantAvg: d("101").Add(d("51")).Div(d("1.5")), wantWorst: d("102")},
+		{name: "total match buy", qty: d("4"), side: "BUY", wantAvg: d("102"), wantWorst: d("103")},
+		{name: "total match sell", qty: d("4"), side: "SELL", wantAvg: d("99"), wantWorst: d("98")},
+		{name: "insufficient liquidity buy", qty: d("5"), side: "BUY", wantNil: true},
+		{name: "insufficient liquidity sell", qty: d("5"), side: "SELL", wantNil: true},
+		{name: "negative qty", qty: d("-1"), side: "BUY", wantNil: true},
+		{name: "zero qty", qty: d("0"), side: "SELL", wantNil: true},
+		{name: "invalid side", qty: d("1"), side: "INVALID_SIDE", wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testOrderBook().PriceImpactQty(tt.qty, tt.side)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("got %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("got nil")
+			}
+			if !got.Qty.Equal(tt.qty) {
+				t.Errorf("Qty = %s, want %s", got.Qty, tt.qty)
+			}
+			if !got.AveragePrice.Equal(tt.wantAvg) {
+				t.Errorf("AveragePrice = %s, want %s", got.AveragePrice, tt.wantAvg)
+			}
+			if !got.WorstPrice.Equal(tt.wantWorst) {
+				t.Errorf("WorstPrice = %s, want %s", got.WorstPrice, tt.wantWorst)
+			}
+		})
+	}
+}
+
+func TestTakerLevelsSortsUnorderedBook(t *testing.T) {
+	book := &OrderBook{
+		Bid: []OrderBookEntry{{Price: d("98"), Qty: d("1")}, {Price: d("100"), Qty: d("0")}, {Price: d("99"), Qty: d("1")}},
+		Ask: []OrderBookEntry{{Price: d("103"), Qty: d("1")}, {Price: d("101"), Qty: d("1")}},
+	}
+
+	if price, ok := book.TakerPrice("BUY"); !ok || !price.Equal(d("101")) {
+		t.Errorf("TakerPrice(BUY) = %s, %v, want 101", price, ok)
+	}
+	// The empty level at 100 is skipped
+	if price, ok := book.TakerPrice("SELL"); !ok || !price.Equal(d("99")) {
+		t.Errorf("TakerPrice(SELL) = %s, %v, want 99", price, ok)
+	}
+	if _, ok := (&OrderBook{}).TakerPrice("BUY"); ok {
+		t.Error("TakerPrice on an empty book reported a price")
+	}
+}
@@ -0,0 +1,11 @@
+package user
+
+// AccountUpdate is the payload of the private account stream.
+// Only the collections relevant to the message type are populated
+// (e.g. Orders for ORDER messages, Trades for TRADE messages).
+type AccountUpdate struct {
+	Orders    []Order    `json:"orders,omitempty"`
+	Positions []Position `json:"positions,omitempty"`
+	Trades    []Trade    `json:"trades,omitempty"`
+	Balance   *Balance   `json:"balance,omitempty"`
+}
@@ -6,6 +6,8 @@ type TradingFee struct {
 	Market       string          `json:"market"`
 	MakerFeeRate decimal.Decimal `json:"makerFeeRate"`
 	TakerFeeRate decimal.Decimal `json:"takerFeeRate"`
+	// BuilderFeeRate is the maximum builder fee rate, returned when fees are queried for a builder
+	BuilderFeeRate decimal.Decimal `json:"builderFeeRate"`
 }
 
 var DefaultFees = TradingFee{
@@ -0,0 +1,9 @@
+package user
+
+import "github.com/shopspring/decimal"
+
+// AccountLeverage is the leverage the account uses for a market
+type AccountLeverage struct {
+	Market   string          `json:"market"`
+	Leverage decimal.Decimal `json:"leverage"`
+}
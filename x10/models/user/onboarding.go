@@ -0,0 +1,85 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// AccountRegistration is the EIP-712 AccountRegistration message signed with the L1 (Ethereum) key
+type AccountRegistration struct {
+	AccountIndex int    `json:"accountIndex"`
+	Wallet       string `json:"wallet"`
+	TosAccepted  bool   `json:"tosAccepted"`
+	Time         string `json:"time"`   // UTC, formatted as 2006-01-02T15:04:05Z
+	Action       string `json:"action"` // REGISTER | CREATE_SUB_ACCOUNT
+}
+
+// OnboardingRequest registers an L1 wallet and its default account (index 0)
+type OnboardingRequest struct {
+	L1Signature     string              `json:"l1Signature"`
+	L2Key           string              `json:"l2Key"`
+	L2Signature     SettlementSignature `json:"l2Signature"`
+	AccountCreation AccountRegistration `json:"accountCreation"`
+	ReferralCode    *string             `json:"referralCode"`
+}
+
+// SubAccountOnboardingRequest creates an additional account for an onboarded L1 wallet
+type SubAccountOnboardingRequest struct {
+	L2Key           string              `json:"l2Key"`
+	L2Signature     SettlementSignature `json:"l2Signature"`
+	AccountCreation AccountRegistration `json:"accountCreation"`
+	Description     string              `json:"description"`
+}
+
+// SubAccount is an account of an onboarded L1 wallet, as returned by the onboarding endpoints
+type SubAccount struct {
+	ID           int      `json:"id"`
+	Description  string   `json:"description"`
+	AccountIndex int      `json:"accountIndex"`
+	Status       string   `json:"status"`
+	L2Key        string   `json:"l2Key"`
+	L2Vault      int      `json:"l2Vault"`
+	APIKeys      []string `json:"apiKeys,omitempty"`
+}
+
+// UnmarshalJSON accepts the account ID as either id or accountId, and the vault as a number or a string.
+func (a *SubAccount) UnmarshalJSON(data []byte) error {
+	type subAccount SubAccount
+	var raw struct {
+		subAccount
+		AccountID *int        `json:"accountId"`
+		L2Vault   json.Number `json:"l2Vault"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*a = SubAccount(raw.subAccount)
+	if raw.AccountID != nil {
+		a.ID = *raw.AccountID
+	}
+	if raw.L2Vault != "" {
+		vault, err := strconv.Atoi(raw.L2Vault.String())
+		if err != nil {
+			return fmt.Errorf("invalid l2Vault %q: %w", raw.L2Vault, err)
+		}
+		a.L2Vault = vault
+	}
+	return nil
+}
+
+// OnboardedClient is the response to onboarding an L1 wallet
+type OnboardedClient struct {
+	L1Address      string     `json:"l1Address"`
+	DefaultAccount SubAccount `json:"defaultAccount"`
+}
+
+// APIKeyRequest creates a trading API key for an account
+type APIKeyRequest struct {
+	Description string `json:"description"`
+}
+
+// APIKeyResponse holds a newly created trading API key
+type APIKeyResponse struct {
+	Key string `json:"key"`
+}
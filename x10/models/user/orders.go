@@ -1,5 +1,63 @@
 package user
 
+import "strings"
+
+// OrderStatusReason explains why an order was rejected or cancelled (OrderStatusReason in the Python SDK).
+type OrderStatusReason string
+
+const (
+	OrderStatusReasonUnknown              OrderStatusReason = "UNKNOWN"
+	OrderStatusReasonNone                 OrderStatusReason = "NONE"
+	OrderStatusReasonUnknownMarket        OrderStatusReason = "UNKNOWN_MARKET"
+	OrderStatusReasonDisabledMarket       OrderStatusReason = "DISABLED_MARKET"
+	OrderStatusReasonNotEnoughFunds       OrderStatusReason = "NOT_ENOUGH_FUNDS"
+	OrderStatusReasonNoLiquidity          OrderStatusReason = "NO_LIQUIDITY"
+	OrderStatusReasonInvalidFee           OrderStatusReason = "INVALID_FEE"
+	OrderStatusReasonInvalidQty           OrderStatusReason = "INVALID_QTY"
+	OrderStatusReasonInvalidPrice         OrderStatusReason = "INVALID_PRICE"
+	OrderStatusReasonInvalidValue         OrderStatusReason = "INVALID_VALUE"
+	OrderStatusReasonUnknownAccount       OrderStatusReason = "UNKNOWN_ACCOUNT"
+	OrderStatusReasonSelfTradeProtection  OrderStatusReason = "SELF_TRADE_PROTECTION"
+	OrderStatusReasonPostOnlyFailed       OrderStatusReason = "POST_ONLY_FAILED"
+	OrderStatusReasonReduceOnlyFailed     OrderStatusReason = "REDUCE_ONLY_FAILED"
+	OrderStatusReasonInvalidExpireTime    OrderStatusReason = "INVALID_EXPIRE_TIME"
+	OrderStatusReasonPositionTpSlConflict OrderStatusReason = "POSITION_TPSL_CONFLICT"
+	OrderStatusReasonInvalidLeverage      OrderStatusReason = "INVALID_LEVERAGE"
+	OrderStatusReasonPrevOrderNotFound    OrderStatusReason = "PREV_ORDER_NOT_FOUND"
+	OrderStatusReasonPrevOrderTriggered   OrderStatusReason = "PREV_ORDER_TRIGGERED"
+	OrderStatusReasonTpSlOtherSideFilled  OrderStatusReason = "TPSL_OTHER_SIDE_FILLED"
+	OrderStatusReasonPrevOrderConflict    OrderStatusReason = "PREV_ORDER_CONFLICT"
+	OrderStatusReasonOrderReplaced        OrderStatusReason = "ORDER_REPLACED"
+	OrderStatusReasonPostOnlyMode         OrderStatusReason = "POST_ONLY_MODE"
+	OrderStatusReasonReduceOnlyMode       OrderStatusReason = "REDUCE_ONLY_MODE"
+	OrderStatusReasonTradingOffMode       OrderStatusReason = "TRADING_OFF_MODE"
+)
+
+var orderStatusReasons = map[OrderStatusReason]bool{
+	OrderStatusReasonNone: true, OrderStatusReasonUnknownMarket: true, OrderStatusReasonDisabledMarket: true,
+	OrderStatusReasonNotEnoughFunds: true, OrderStatusReasonNoLiquidity: true, OrderStatusReasonInvalidFee: true,
+	OrderStatusReasonInvalidQty: true, OrderStatusReasonInvalidPrice: true, OrderStatusReasonInvalidValue: true,
+	OrderStatusReasonUnknownAccount: true, OrderStatusReasonSelfTradeProtection: true, OrderStatusReasonPostOnlyFailed: true,
+	OrderStatusReasonReduceOnlyFailed: true, OrderStatusReasonInvalidExpireTime: true, OrderStatusReasonPositionTpSlConflict: true,
+	OrderStatusReasonInvalidLeverage: true, OrderStatusReasonPrevOrderNotFound: true, OrderStatusReasonPrevOrderTriggered: true,
+	OrderStatusReasonTpSlOtherSideFilled: true, OrderStatusReasonPrevOrderConflict: true, OrderStatusReasonOrderReplaced: true,
+	OrderStatusReasonPostOnlyMode: true, OrderStatusReasonReduceOnlyMode: true, OrderStatusReasonTradingOffMode: true,
+}
+
+// ParseOrderStatusReason finds the first known reason named in text, e.g. the message of an exchange
+// error ("Order rejected: NOT_ENOUGH_FUNDS"). It returns OrderStatusReasonUnknown when none is named.
+func ParseOrderStatusReason(text string) OrderStatusReason {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r == '_')
+	})
+	for _, word := range words {
+		if reason := OrderStatusReason(word); orderStatusReasons[reason] {
+			return reason
+		}
+	}
+	return OrderStatusReasonUnknown
+}
+
 // TriggerConfig describes trigger-related fields for conditional/TPSL orders
 type TriggerConfig struct {
 	TriggerPrice          string `json:"triggerPrice"`
@@ -26,25 +84,31 @@ type SlConfig struct {
 
 // Order represents an open order
 type Order struct {
-	ID           int64          `json:"id"`
-	AccountID    int            `json:"accountId"`
-	ExternalID   string         `json:"externalId"`
-	Market       string         `json:"market"`
-	Type         string         `json:"type"` // LIMIT | CONDITIONAL | TPSL | TWAP
-	Side         string         `json:"side"` // BUY | SELL
-	Status       string         `json:"status"`
-	Price        string         `json:"price"`
-	AveragePrice string         `json:"averagePrice"`
-	Qty          string         `json:"qty"`
-	FilledQty    string         `json:"filledQty"`
-	PayedFee     string         `json:"payedFee"`
-	Trigger      *TriggerConfig `json:"trigger,omitempty"`
-	TakeProfit   *TpConfig      `json:"takeProfit,omitempty"`
-	StopLoss     *SlConfig      `json:"stopLoss,omitempty"`
-	ReduceOnly   bool           `json:"reduceOnly"`
-	PostOnly     bool           `json:"postOnly"`
-	CreatedTime  int64          `json:"createdTime"`
-	UpdatedTime  int64          `json:"updatedTime"`
-	TimeInForce  string         `json:"timeInForce"`
-	ExpireTime   int64          `json:"expireTime"`
+	ID           int64             `json:"id"`
+	AccountID    int               `json:"accountId"`
+	ExternalID   string            `json:"externalId"`
+	Market       string            `json:"market"`
+	Type         string            `json:"type"` // LIMIT | CONDITIONAL | TPSL | TWAP
+	Side         string            `json:"side"` // BUY | SELL
+	Status       string            `json:"status"`
+	StatusReason OrderStatusReason `json:"statusReason,omitempty"`
+	Price        string            `json:"price"`
+	AveragePrice string            `json:"averagePrice"`
+	Qty          string            `json:"qty"`
+	FilledQty    string            `json:"filledQty"`
+	PayedFee     string            `json:"payedFee"`
+	Trigger      *TriggerConfig    `json:"trigger,omitempty"`
+	TpSlType     string            `json:"tpSlType,omitempty"` // ORDER | POSITION
+	TakeProfit   *TpConfig         `json:"takeProfit,omitempty"`
+	StopLoss     *SlConfig         `json:"stopLoss,omitempty"`
+	ReduceOnly   bool              `json:"reduceOnly"`
+	PostOnly     bool              `json:"postOnly"`
+	CreatedTime  int64             `json:"createdTime"`
+	UpdatedTime  int64             `json:"updatedTime"`
+	TimeInForce  string            `json:"timeInForce"`
+	ExpireTime   int64             `json:"expireTime"`
+
+	SelfTradeProtectionLevel string `json:"selfTradeProtectionLevel,omitempty"` // DISABLED | ACCOUNT | CLIENT
+	BuilderFee               string `json:"builderFee,omitempty"`
+	BuilderID                int    `json:"builderId,omitempty"`
 }
@@ -28,10 +28,17 @@ type TpslConfig struct {
 	Settlement       *Settlement `json:"settlement,omitempty"`
 }
 
+// TwapConfig describes how a TWAP parent order is sliced over time
+type TwapConfig struct {
+	DurationSeconds  int64 `json:"durationSeconds"`
+	FrequencySeconds int64 `json:"frequencySeconds"`
+	Randomize        bool  `json:"randomize"`
+}
+
 type CreateOrderRequest struct {
 	ID                       string            `json:"id"`
 	Market                   string            `json:"market"`
-	Type                     string            `json:"type"` // LIMIT | MARKET | CONDITIONAL | TPSL
+	Type                     string            `json:"type"` // LIMIT | MARKET | CONDITIONAL | TPSL | TWAP
 	Side                     string            `json:"side"` // BUY | SELL
 	Qty                      string            `json:"qty"`
 	Price                    string            `json:"price"`
@@ -48,6 +55,7 @@ type CreateOrderRequest struct {
 	TpSlType                 string            `json:"tpSlType,omitempty"` // ORDER | POSITION
 	TakeProfit               *TpslConfig       `json:"takeProfit,omitempty"`
 	StopLoss                 *TpslConfig       `json:"stopLoss,omitempty"`
+	Twap                     *TwapConfig       `json:"twap,omitempty"`
 	DebuggingAmounts         *DebuggingAmounts `json:"debuggingAmounts,omitempty"`
 	BuilderFee               string            `json:"builderFee,omitempty"`
 	BuilderID                int               `json:"builderId,omitempty"`
@@ -0,0 +1,35 @@
+package user
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseOrderStatusReason(t *testing.T) {
+	tests := []struct {
+		text string
+		want OrderStatusReason
+	}{
+		{"NOT_ENOUGH_FUNDS", OrderStatusReasonNotEnoughFunds},
+		{"Order rejected: POST_ONLY_FAILED", OrderStatusReasonPostOnlyFailed},
+		{"reason=REDUCE_ONLY_FAILED.", OrderStatusReasonReduceOnlyFailed},
+		{"INVALID_PRICE_TOO_HIGH", OrderStatusReasonUnknown},
+		{"Invalid price", OrderStatusReasonUnknown},
+		{"", OrderStatusReasonUnknown},
+	}
+	for _, tt := range tests {
+		if got := ParseOrderStatusReason(tt.text); got != tt.want {
+			t.Errorf("ParseOrderStatusReason(%q) = %s, want %s", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestOrderStatusReasonJSON(t *testing.T) {
+	var order Order
+	if err := json.Unmarshal([]byte(`{"id":1,"status":"REJECTED","statusReason":"INVALID_PRICE"}`), &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.StatusReason != OrderStatusReasonInvalidPrice {
+		t.Errorf("StatusReason = %s, want %s", order.StatusReason, OrderStatusReasonInvalidPrice)
+	}
+}
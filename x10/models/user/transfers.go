@@ -0,0 +1,25 @@
+package user
+
+import "github.com/shopspring/decimal"
+
+// TransferSettlement is the signed Stark transfer between two positions
+type TransferSettlement struct {
+	Amount              int64               `json:"amount"` // in collateral Stark units
+	AssetID             string              `json:"assetId"`
+	ExpirationTimestamp int64               `json:"expirationTimestamp"` // hours since epoch
+	Nonce               int64               `json:"nonce"`
+	ReceiverPositionID  int                 `json:"receiverPositionId"`
+	ReceiverPublicKey   string              `json:"receiverPublicKey"`
+	SenderPositionID    int                 `json:"senderPositionId"`
+	SenderPublicKey     string              `json:"senderPublicKey"`
+	Signature           SettlementSignature `json:"signature"`
+}
+
+// TransferRequest moves collateral from one vault (sub-account) to another
+type TransferRequest struct {
+	FromVault        int                `json:"fromVault"`
+	ToVault          int                `json:"toVault"`
+	Amount           decimal.Decimal    `json:"amount"`
+	Settlement       TransferSettlement `json:"settlement"`
+	TransferredAsset string             `json:"transferredAsset"`
+}
@@ -0,0 +1,22 @@
+package user
+
+import "github.com/shopspring/decimal"
+
+// WithdrawalSettlement is the signed Stark withdrawal to an Ethereum address
+type WithdrawalSettlement struct {
+	Amount              int64               `json:"amount"` // in collateral Stark units
+	CollateralAssetID   string              `json:"collateralAssetId"`
+	EthAddress          string              `json:"ethAddress"`
+	ExpirationTimestamp int64               `json:"expirationTimestamp"` // hours since epoch
+	Nonce               int64               `json:"nonce"`
+	PositionID          int                 `json:"positionId"`
+	PublicKey           string              `json:"publicKey"`
+	Signature           SettlementSignature `json:"signature"`
+}
+
+// SlowWithdrawalRequest withdraws collateral to an Ethereum address through the on-chain (slow) path
+type SlowWithdrawalRequest struct {
+	Amount      decimal.Decimal      `json:"amount"`
+	Settlement  WithdrawalSettlement `json:"settlement"`
+	Description string               `json:"description,omitempty"`
+}
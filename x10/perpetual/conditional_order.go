@@ -0,0 +1,116 @@
+package perpetual
+
+import (
+	"fmt"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// TriggerParams describes when a conditional order is activated and how it executes.
+type TriggerParams struct {
+	TriggerPrice       decimal.Decimal
+	TriggerPriceType   string // LAST | INDEX | MARK
+	Direction          string // UP | DOWN: activate when the price rises / falls to TriggerPrice
+	ExecutionPriceType string // LIMIT | MARKET
+}
+
+func (t TriggerParams) validate() error {
+	if !t.TriggerPrice.IsPositive() {
+		return fmt.Errorf("trigger price must be positive")
+	}
+	switch t.TriggerPriceType {
+	case "LAST", "INDEX", "MARK":
+	default:
+		return fmt.Errorf("invalid trigger price type: %s", t.TriggerPriceType)
+	}
+	switch t.Direction {
+	case "UP", "DOWN":
+	default:
+		return fmt.Errorf("invalid trigger direction: %s", t.Direction)
+	}
+	switch t.ExecutionPriceType {
+	case "LIMIT", "MARKET":
+	default:
+		return fmt.Errorf("invalid execution price type: %s", t.ExecutionPriceType)
+	}
+	return nil
+}
+
+// CreateConditionalOrder creates a CONDITIONAL order that rests until trigger is hit and then executes.
+// price is the limit price for LIMIT execution, or the protective worst price for MARKET execution;
+// it is signed the same way as a regular order.
+func CreateConditionalOrder(
+	account *starknet.StarknetPerpetualAccount,
+	market *info.Market,
+	amountOfSynthetic decimal.Decimal,
+	price decimal.Decimal,
+	side string,
+	trigger TriggerParams,
+	opts *PlaceOrderOptions,
+) (*user.CreateOrderRequest, error) {
+	if market == nil {
+		return nil, fmt.Errorf("market is required")
+	}
+
+	if account == nil || account.Signer == nil {
+		return nil, fmt.Errorf("account with a signer is required")
+	}
+
+	if err := trigger.validate(); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &PlaceOrderOptions{}
+	}
+	if opts.PostOnly != nil && *opts.PostOnly && trigger.ExecutionPriceType == "MARKET" {
+		return nil, fmt.Errorf("conditional orders with MARKET execution cannot be post-only")
+	}
+
+	amountOfSynthetic, price, err := prepareOrder(market, "CONDITIONAL", amountOfSynthetic, price, side, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := orderFees(account, market, opts)
+
+	req, err := createOrder(
+		market,
+		"CONDITIONAL",
+		amountOfSynthetic,
+		price,
+		side,
+		account.Vault,
+		fees,
+		account.Signer,
+		false,
+		opts.ExpireTime,
+		opts.PostOnly != nil && *opts.PostOnly,
+		opts.PreviousOrderID,
+		opts.OrderExternalID,
+		opts.TimeInForce,
+		opts.SelfTradeProtectionLevel,
+		opts.ReduceOnly != nil && *opts.ReduceOnly,
+		opts.BuilderFee,
+		opts.BuilderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachTpSl(req, market, amountOfSynthetic, fees, account, opts); err != nil {
+		return nil, err
+	}
+
+	// The trigger is not part of the signed message; the settlement only covers the order amounts.
+	req.Trigger = &user.Trigger{
+		TriggerPrice:       trigger.TriggerPrice.String(),
+		TriggerPriceType:   trigger.TriggerPriceType,
+		Direction:          trigger.Direction,
+		ExecutionPriceType: trigger.ExecutionPriceType,
+	}
+	return req, nil
+}
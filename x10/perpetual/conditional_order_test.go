@@ -0,0 +1,75 @@
+package perpetual
+
+import (
+	"testing"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/shopspring/decimal"
+)
+
+func TestCreateConditionalOrder(t *testing.T) {
+	account := testAccount(t, 10002)
+	postOnly := true
+	stopBuy := TriggerParams{
+		TriggerPrice:       decimal.RequireFromString("51000"),
+		TriggerPriceType:   "MARK",
+		Direction:          "UP",
+		ExecutionPriceType: "LIMIT",
+	}
+	with := func(change func(p *TriggerParams)) TriggerParams {
+		p := stopBuy
+		change(&p)
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		price   string
+		trigger TriggerParams
+		opts    *PlaceOrderOptions
+		wantErr bool
+	}{
+		{name: "limit execution", price: "51500", trigger: stopBuy, opts: &PlaceOrderOptions{ExpireTime: &testExpiry}},
+		{name: "post-only limit execution", price: "51500", trigger: stopBuy, opts: &PlaceOrderOptions{ExpireTime: &testExpiry, PostOnly: &postOnly}},
+		{name: "market execution", price: "52000", trigger: with(func(p *TriggerParams) { p.ExecutionPriceType = "MARKET" }), opts: &PlaceOrderOptions{ExpireTime: &testExpiry}},
+		{name: "post-only market execution", price: "52000", trigger: with(func(p *TriggerParams) { p.ExecutionPriceType = "MARKET" }), opts: &PlaceOrderOptions{PostOnly: &postOnly}, wantErr: true},
+		{name: "no trigger price", price: "51500", trigger: with(func(p *TriggerParams) { p.TriggerPrice = decimal.Zero }), wantErr: true},
+		{name: "invalid trigger price type", price: "51500", trigger: with(func(p *TriggerParams) { p.TriggerPriceType = "LAST_TRADE" }), wantErr: true},
+		{name: "invalid direction", price: "51500", trigger: with(func(p *TriggerParams) { p.Direction = "SIDEWAYS" }), wantErr: true},
+		{name: "invalid execution type", price: "51500", trigger: with(func(p *TriggerParams) { p.ExecutionPriceType = "STOP" }), wantErr: true},
+		{name: "price off the tick", price: "51500.5", trigger: stopBuy, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := signingMarket()
+			qty, price := decimal.RequireFromString("0.01"), decimal.RequireFromString(tt.price)
+			req, err := CreateConditionalOrder(account, market, qty, price, "BUY", tt.trigger, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := user.Trigger{
+				TriggerPrice:       tt.trigger.TriggerPrice.String(),
+				TriggerPriceType:   tt.trigger.TriggerPriceType,
+				Direction:          tt.trigger.Direction,
+				ExecutionPriceType: tt.trigger.ExecutionPriceType,
+			}
+			if req.Type != "CONDITIONAL" || req.Trigger == nil || *req.Trigger != want {
+				t.Errorf("got type %s, trigger %+v; want CONDITIONAL with %+v", req.Type, req.Trigger, want)
+			}
+			// The trigger is not signed: the settlement covers the order amounts only
+			feeRate := user.DefaultFees.TakerFeeRate
+			if req.PostOnly {
+				feeRate = user.DefaultFees.MakerFeeRate
+			}
+			verifySettlement(t, account, market, "BUY", qty, price, feeRate, req.Nonce, req.ExpiryEpochMillis, &req.Settlement)
+		})
+	}
+}
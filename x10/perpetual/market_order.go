@@ -0,0 +1,151 @@
+package perpetual
+
+import (
+	"fmt"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// MarketOrderPrice derives the protective limit price for a market order of qty.
+// The reference price is the top of book on the side the order takes from, falling back to
+// markPrice when that side of the book is empty (or book is nil). The reference is moved by
+// maxSlippage (e.g. 0.01 for 1%) against the taker and rounded to TradingConfig.MinPriceChange
+// towards the reference, so the resulting price never exceeds the slippage bound.
+// An error is returned when the book cannot fill qty within that price.
+func MarketOrderPrice(market *info.Market, book *info.OrderBook, markPrice decimal.Decimal, qty decimal.Decimal, side string, maxSlippage decimal.Decimal) (decimal.Decimal, error) {
+	if market == nil {
+		return decimal.Zero, fmt.Errorf("market is required")
+	}
+	if side != "BUY" && side != "SELL" {
+		return decimal.Zero, fmt.Errorf("invalid order side: %s", side)
+	}
+	if maxSlippage.IsNegative() || maxSlippage.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return decimal.Zero, fmt.Errorf("max slippage must be in [0, 1): %s", maxSlippage)
+	}
+
+	reference := markPrice
+	var impact *info.PriceImpact
+	if book != nil {
+		if best, ok := book.TakerPrice(side); ok {
+			reference = best
+			if impact = book.PriceImpactQty(qty, side); impact == nil {
+				return decimal.Zero, fmt.Errorf("order book for %s lacks the liquidity to fill %s", market.Name, qty)
+			}
+		}
+	}
+	if !reference.IsPositive() {
+		return decimal.Zero, fmt.Errorf("no reference price available for %s", market.Name)
+	}
+
+	one := decimal.NewFromInt(1)
+	var price decimal.Decimal
+	if side == "BUY" {
+		price = roundToStep(reference.Mul(one.Add(maxSlippage)), market.TradingConfig.MinPriceChange, false)
+	} else {
+		price = roundToStep(reference.Mul(one.Sub(maxSlippage)), market.TradingConfig.MinPriceChange, true)
+	}
+	if !price.IsPositive() {
+		return decimal.Zero, fmt.Errorf("protective price for %s is not positive", market.Name)
+	}
+
+	if impact != nil {
+		if (side == "BUY" && impact.WorstPrice.GreaterThan(price)) || (side == "SELL" && impact.WorstPrice.LessThan(price)) {
+			return decimal.Zero, fmt.Errorf("order book for %s cannot fill %s within %s slippage (worst fill %s, limit %s)",
+				market.Name, qty, maxSlippage, impact.WorstPrice, price)
+		}
+	}
+
+	return price, nil
+}
+
+// CreateMarketOrder creates an IOC order of type MARKET with price as its protective limit.
+// Use MarketOrderPrice to derive the price. The order value (qty * price) must not exceed
+// TradingConfig.MaxMarketOrderValue. PostOnly and TimeInForce in opts are not allowed.
+func CreateMarketOrder(
+	account *starknet.StarknetPerpetualAccount,
+	market *info.Market,
+	amountOfSynthetic decimal.Decimal,
+	price decimal.Decimal,
+	side string,
+	opts *PlaceOrderOptions,
+) (*user.CreateOrderRequest, error) {
+	if market == nil {
+		return nil, fmt.Errorf("market is required")
+	}
+
+	if account == nil || account.Signer == nil {
+		return nil, fmt.Errorf("account with a signer is required")
+	}
+
+	if opts == nil {
+		opts = &PlaceOrderOptions{}
+	}
+	if opts.PostOnly != nil && *opts.PostOnly {
+		return nil, fmt.Errorf("market orders cannot be post-only")
+	}
+	if opts.TimeInForce != nil && *opts.TimeInForce != "IOC" {
+		return nil, fmt.Errorf("market orders must be IOC")
+	}
+
+	amountOfSynthetic, price, err := prepareOrder(market, "MARKET", amountOfSynthetic, price, side, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := orderFees(account, market, opts)
+
+	timeInForce := "IOC"
+	req, err := createOrder(
+		market,
+		"MARKET",
+		amountOfSynthetic,
+		price,
+		side,
+		account.Vault,
+		fees,
+		account.Signer,
+		false,
+		opts.ExpireTime,
+		false,
+		opts.PreviousOrderID,
+		opts.OrderExternalID,
+		&timeInForce,
+		opts.SelfTradeProtectionLevel,
+		opts.ReduceOnly != nil && *opts.ReduceOnly,
+		opts.BuilderFee,
+		opts.BuilderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachTpSl(req, market, amountOfSynthetic, fees, account, opts); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// roundToStep rounds value to a multiple of step, up or down. A non-positive step leaves value unchanged.
+func roundToStep(value, step decimal.Decimal, up bool) decimal.Decimal {
+	if !step.IsPositive() {
+		return value
+	}
+	steps := value.Div(step)
+	if up {
+		steps = steps.Ceil()
+	} else {
+		steps = steps.Floor()
+	}
+	return steps.Mul(step)
+}
+
+// roundToNearestStep rounds value to the nearest multiple of step. A non-positive step leaves value unchanged.
+func roundToNearestStep(value, step decimal.Decimal) decimal.Decimal {
+	if !step.IsPositive() {
+		return value
+	}
+	return value.Div(step).Round(0).Mul(step)
+}
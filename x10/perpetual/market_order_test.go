@@ -0,0 +1,180 @@
+package perpetual
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/starknet.go/curve"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// testAccount returns an account for vault signing with the Python SDK's test trading key.
+func testAccount(t *testing.T, vault int) *starknet.StarknetPerpetualAccount {
+	t.Helper()
+	privateKey, _ := new(big.Int).SetString("7a7ff6fd3cab02ccdcd4a572563f5976f8976899b03a39773795a3c486d4986", 16)
+	signer, err := starknet.NewPrivateKeySigner(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := starknet.NewStarknetAccountWithSigner(vault, "", signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account
+}
+
+// signingMarket is a BTC-USD market with the L2 config of the Python SDK's BTC-USD fixture.
+func signingMarket() *info.Market {
+	return &info.Market{
+		Name:                     "BTC-USD",
+		AssetPrecision:           5,
+		CollateralAssetPrecision: 6,
+		TradingConfig: info.TradingConfig{
+			MinOrderSize:        decimal.RequireFromString("0.0001"),
+			MinOrderSizeChange:  decimal.RequireFromString("0.0001"),
+			MinPriceChange:      decimal.RequireFromString("1"),
+			MaxMarketOrderValue: decimal.RequireFromString("1000000"),
+			MaxLimitOrderValue:  decimal.RequireFromString("5000000"),
+			MaxNumOrders:        "200",
+			LimitPriceCap:       decimal.RequireFromString("0.05"),
+			LimitPriceFloor:     decimal.RequireFromString("0.05"),
+		},
+		L2Config: info.L2Config{
+			Type:                 "STARKX",
+			CollateralID:         "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+			CollateralResolution: 1000000,
+			SyntheticID:          "0x4254432d3600000000000000000000",
+			SyntheticResolution:  1000000,
+		},
+	}
+}
+
+// testExpiry is a whole-second expiry, so the millisecond expiry of a request rebuilds the signed one.
+var testExpiry = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// verifySettlement fails the test unless settlement is a signature by account over an order of qty
+// at price on side, signed with feeRate, nonce and the request's expiry.
+func verifySettlement(t *testing.T, account *starknet.StarknetPerpetualAccount, market *info.Market, side string,
+	qty, price, feeRate decimal.Decimal, nonce string, expiryMillis int64, settlement *user.Settlement) {
+	t.Helper()
+	if settlement == nil {
+		t.Fatal("settlement is missing")
+	}
+	n, err := strconv.ParseInt(nonce, 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isBuying := side == "BUY"
+	expiry := time.UnixMilli(expiryMillis)
+	hash, err := starknet.HashOrder(models.NewStarkOrderAmounts(market, qty, price, feeRate, isBuying), isBuying, &expiry, n, account.Vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := new(big.Int).SetString(settlement.Signature.R, 0)
+	s, _ := new(big.Int).SetString(settlement.Signature.S, 0)
+	if r == nil || s == nil {
+		t.Fatalf("malformed signature %+v", settlement.Signature)
+	}
+	if ok, err := curve.Verify(hash.BigInt(new(big.Int)), r, s, account.PublicKey); err != nil || !ok {
+		t.Errorf("settlement does not sign %s %s@%s with fee %s", side, qty, price, feeRate)
+	}
+}
+
+func TestMarketOrderPrice(t *testing.T) {
+	book := &info.OrderBook{
+		Market: "BTC-USD",
+		Bid:    []info.OrderBookEntry{entry("99", "2"), entry("100", "1")},
+		Ask:    []info.OrderBookEntry{entry("102", "3"), entry("101", "1")},
+	}
+
+	tests := []struct {
+		name      string
+		book      *info.OrderBook
+		markPrice string
+		qty       string
+		side      string
+		slippage  string
+		want      string // empty when an error is expected
+	}{
+		{name: "buy from best ask", book: book, qty: "1", side: "BUY", slippage: "0.05", want: "106"},
+		{name: "sell from best bid", book: book, qty: "1", side: "SELL", slippage: "0.05", want: "95"},
+		{name: "sell rounds up to the tick", book: book, qty: "1", side: "SELL", slippage: "0.015", want: "99"},
+		{name: "buy through two levels", book: book, qty: "3", side: "BUY", slippage: "0.01", want: "102"},
+		{name: "worst fill beyond slippage", book: book, qty: "3", side: "BUY", slippage: "0.005"},
+		{name: "not enough liquidity", book: book, qty: "10", side: "BUY", slippage: "0.5"},
+		{name: "empty side uses mark price", book: &info.OrderBook{Bid: book.Bid}, markPrice: "50000", qty: "1", side: "BUY", slippage: "0.01", want: "50500"},
+		{name: "no book uses mark price", markPrice: "50000", qty: "1", side: "SELL", slippage: "0", want: "50000"},
+		{name: "no reference price", qty: "1", side: "BUY", slippage: "0.01"},
+		{name: "invalid side", book: book, qty: "1", side: "LONG", slippage: "0.01"},
+		{name: "slippage of 100%", book: book, qty: "1", side: "SELL", slippage: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markPrice := decimal.Zero
+			if tt.markPrice != "" {
+				markPrice = decimal.RequireFromString(tt.markPrice)
+			}
+			got, err := MarketOrderPrice(signingMarket(), tt.book, markPrice,
+				decimal.RequireFromString(tt.qty), tt.side, decimal.RequireFromString(tt.slippage))
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("price = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateMarketOrder(t *testing.T) {
+	account := testAccount(t, 10002)
+	gtt, ioc, postOnly := "GTT", "IOC", true
+
+	tests := []struct {
+		name    string
+		qty     string
+		opts    *PlaceOrderOptions
+		wantErr bool
+	}{
+		{name: "default options", qty: "0.01", opts: &PlaceOrderOptions{ExpireTime: &testExpiry}},
+		{name: "explicit IOC", qty: "0.01", opts: &PlaceOrderOptions{ExpireTime: &testExpiry, TimeInForce: &ioc}},
+		{name: "post-only", qty: "0.01", opts: &PlaceOrderOptions{PostOnly: &postOnly}, wantErr: true},
+		{name: "GTT", qty: "0.01", opts: &PlaceOrderOptions{TimeInForce: &gtt}, wantErr: true},
+		{name: "above max market order value", qty: "25", opts: &PlaceOrderOptions{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := signingMarket()
+			qty, price := decimal.RequireFromString(tt.qty), decimal.RequireFromString("50000")
+			req, err := CreateMarketOrder(account, market, qty, price, "BUY", tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.Type != "MARKET" || req.TimeInForce != "IOC" || req.PostOnly {
+				t.Errorf("got type %s, time in force %s, post-only %v; want an IOC MARKET order", req.Type, req.TimeInForce, req.PostOnly)
+			}
+			verifySettlement(t, account, market, "BUY", qty, price, user.DefaultFees.TakerFeeRate, req.Nonce, req.ExpiryEpochMillis, &req.Settlement)
+		})
+	}
+}
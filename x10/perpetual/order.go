@@ -21,6 +21,16 @@ type PlaceOrderOptions struct {
 	OrderExternalID          *string
 	TimeInForce              *string
 	SelfTradeProtectionLevel *string
+	ReduceOnly               *bool
+	BuilderFee               *decimal.Decimal // builder fee rate (e.g. 0.0001), requires BuilderID
+	BuilderID                *int
+	AutoRound                *bool            // round qty and price to the market increments instead of rejecting them
+	MarkPrice                *decimal.Decimal // when set, the price is checked against LimitPriceCap / LimitPriceFloor
+	OpenOrders               *int             // orders already open on the market; when set, checked against MaxNumOrders
+	TradingFee               *user.TradingFee // fee rates to sign with; defaults to the account's TradingFees for the market
+	TpSlType                 *string          // ORDER | POSITION, required when TakeProfit or StopLoss is set
+	TakeProfit               *TpSlLeg
+	StopLoss                 *TpSlLeg
 }
 
 // CreateOrder creates an order object to be placed on the exchange.
@@ -37,24 +47,30 @@ func CreateOrder(
 		return nil, fmt.Errorf("market is required")
 	}
 
+	if account == nil || account.Signer == nil {
+		return nil, fmt.Errorf("account with a signer is required")
+	}
+
 	if opts == nil {
 		opts = &PlaceOrderOptions{}
 	}
 
-	fees := account.TradingFees[market.Name]
-	if fees == (user.TradingFee{}) {
-		fees = user.DefaultFees
+	amountOfSynthetic, price, err := prepareOrder(market, "LIMIT", amountOfSynthetic, price, side, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	return createOrder(
+	fees := orderFees(account, market, opts)
+
+	req, err := createOrder(
 		market,
+		"LIMIT",
 		amountOfSynthetic,
 		price,
 		side,
 		account.Vault,
 		fees,
-		account.Sign,
-		account.PublicKey,
+		account.Signer,
 		false,
 		opts.ExpireTime,
 		opts.PostOnly != nil && *opts.PostOnly,
@@ -62,19 +78,30 @@ func CreateOrder(
 		opts.OrderExternalID,
 		opts.TimeInForce,
 		opts.SelfTradeProtectionLevel,
+		opts.ReduceOnly != nil && *opts.ReduceOnly,
+		opts.BuilderFee,
+		opts.BuilderID,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachTpSl(req, market, amountOfSynthetic, fees, account, opts); err != nil {
+		return nil, err
+	}
+	return req, nil
 }
 
 // todo: add godocs + continue matching python sdk
 func createOrder(
 	market *info.Market,
+	orderType string,
 	syntheticAmount decimal.Decimal,
 	price decimal.Decimal,
 	side string,
 	collateralPositionID int,
 	fees user.TradingFee,
-	signer func(*felt.Felt) (*big.Int, *big.Int, error),
-	publicKey *big.Int,
+	signer starknet.Signer,
 	exactOnly bool,
 	expireTime *time.Time,
 	postOnly bool,
@@ -82,11 +109,18 @@ func createOrder(
 	orderExternalID *string,
 	timeInForce *string,
 	selfTradeProtectionLevel *string,
+	reduceOnly bool,
+	builderFee *decimal.Decimal,
+	builderID *int,
 ) (*user.CreateOrderRequest, error) {
 	if exactOnly {
 		return nil, fmt.Errorf("exact_only option is not supported yet")
 	}
 
+	if err := validateBuilder(builderFee, builderID); err != nil {
+		return nil, err
+	}
+
 	if expireTime == nil {
 		defaultExpire := time.Now().Add(8 * time.Hour)
 		expireTime = &defaultExpire
@@ -109,7 +143,14 @@ func createOrder(
 
 	isBuyingSynthetic := side == "BUY"
 
-	amounts := models.NewStarkOrderAmounts(market, syntheticAmount, price, fees.TakerFeeRate, isBuyingSynthetic)
+	// Post-only orders always rest on the book, so they can only be charged the maker fee
+	feeRate := fees.TakerFeeRate
+	if postOnly {
+		feeRate = fees.MakerFeeRate
+	}
+
+	// The signed max fee must cover both the exchange fee and the builder fee
+	amounts := models.NewStarkOrderAmounts(market, syntheticAmount, price, totalFeeRate(feeRate, builderFee), isBuyingSynthetic)
 
 	collateralAmountDebug := decimal.NewFromBigInt(amounts.CollateralAmountInternal.ToStarkAmount(amounts.RoundingMode).Value, 0)
 	if isBuyingSynthetic {
@@ -122,23 +163,9 @@ func createOrder(
 		SyntheticAmount:  decimal.NewFromBigInt(amounts.SyntheticAmountInternal.ToStarkAmount(amounts.RoundingMode).Value, 0),
 	}
 
-	orderHash, err := starknet.HashOrder(amounts, isBuyingSynthetic, expireTime, nonce, collateralPositionID)
+	orderHash, settlement, err := settle(amounts, isBuyingSynthetic, expireTime, nonce, collateralPositionID, signer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create order hash: %w", err)
-	}
-
-	r, s, err := signer(orderHash)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign order: %w", err)
-	}
-
-	settlement := user.Settlement{
-		Signature: user.SettlementSignature{
-			R: fmt.Sprintf("0x%x", r),
-			S: fmt.Sprintf("0x%x", s),
-		},
-		StarkKey:           fmt.Sprintf("0x%x", publicKey),
-		CollateralPosition: fmt.Sprintf("%d", collateralPositionID),
+		return nil, err
 	}
 
 	var orderID string
@@ -153,24 +180,86 @@ func createOrder(
 	req := user.CreateOrderRequest{
 		ID:                       orderID,
 		Market:                   market.Name,
-		Type:                     "LIMIT",
+		Type:                     orderType,
 		Side:                     side,
 		Qty:                      syntheticAmount.String(),
 		Price:                    price.String(),
 		PostOnly:                 postOnly,
 		TimeInForce:              *timeInForce,
 		ExpiryEpochMillis:        expireTime.UnixMilli(),
-		Fee:                      fees.TakerFeeRate,
+		Fee:                      feeRate,
 		SelfTradeProtectionLevel: *selfTradeProtectionLevel,
 		Nonce:                    fmt.Sprintf("%d", nonce),
 		CancelID:                 getStringValue(previousOrderExternalID),
 		Settlement:               settlement,
 		DebuggingAmounts:         debuggingAmounts,
+		ReduceOnly:               reduceOnly,
+	}
+
+	if builderFee != nil {
+		req.BuilderFee = builderFee.String()
+		req.BuilderID = *builderID
 	}
 
 	return &req, nil
 }
 
+// settle hashes the order amounts and signs the hash, returning it along with the settlement carrying the signature.
+func settle(amounts models.StarkOrderAmounts, isBuyingSynthetic bool, expireTime *time.Time, nonce int64, collateralPositionID int, signer starknet.Signer) (*felt.Felt, user.Settlement, error) {
+	orderHash, err := starknet.HashOrder(amounts, isBuyingSynthetic, expireTime, nonce, collateralPositionID)
+	if err != nil {
+		return nil, user.Settlement{}, fmt.Errorf("failed to create order hash: %w", err)
+	}
+
+	r, s, err := signer.Sign(orderHash)
+	if err != nil {
+		return nil, user.Settlement{}, fmt.Errorf("failed to sign order: %w", err)
+	}
+
+	settlement := user.Settlement{
+		Signature: user.SettlementSignature{
+			R: fmt.Sprintf("0x%x", r),
+			S: fmt.Sprintf("0x%x", s),
+		},
+		StarkKey:           fmt.Sprintf("0x%x", signer.PublicKey()),
+		CollateralPosition: fmt.Sprintf("%d", collateralPositionID),
+	}
+
+	return orderHash, settlement, nil
+}
+
+// validateBuilder checks that a builder fee and builder ID are given together and that the fee is not negative.
+func validateBuilder(builderFee *decimal.Decimal, builderID *int) error {
+	if (builderFee == nil) != (builderID == nil) {
+		return fmt.Errorf("builder fee and builder id must be set together")
+	}
+	if builderFee != nil && builderFee.IsNegative() {
+		return fmt.Errorf("builder fee must not be negative")
+	}
+	return nil
+}
+
+// totalFeeRate returns the fee rate to sign: the exchange fee rate plus the builder fee rate, if any.
+func totalFeeRate(feeRate decimal.Decimal, builderFee *decimal.Decimal) decimal.Decimal {
+	if builderFee == nil {
+		return feeRate
+	}
+	return feeRate.Add(*builderFee)
+}
+
+// orderFees returns opts.TradingFee when set, otherwise the account's trading fees for market,
+// or the default fees when none are known.
+func orderFees(account *starknet.StarknetPerpetualAccount, market *info.Market, opts *PlaceOrderOptions) user.TradingFee {
+	if opts.TradingFee != nil {
+		return *opts.TradingFee
+	}
+	fees := account.TradingFees[market.Name]
+	if fees == (user.TradingFee{}) {
+		return user.DefaultFees
+	}
+	return fees
+}
+
 // getStringValue safely extracts string value from pointer
 func getStringValue(s *string) string {
 	if s == nil {
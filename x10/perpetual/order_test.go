@@ -0,0 +1,73 @@
+package perpetual
+
+import (
+	"testing"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/shopspring/decimal"
+)
+
+func TestCreateOrderBuilderFee(t *testing.T) {
+	account := testAccount(t, 10002)
+	builderFee, negativeFee := decimal.RequireFromString("0.0001"), decimal.RequireFromString("-0.0001")
+	builderID := 7
+	postOnly, reduceOnly := true, true
+	orderType := "ORDER"
+
+	tests := []struct {
+		name       string
+		opts       PlaceOrderOptions
+		wantFee    string // fee reported in the request
+		wantSigned string // fee rate covered by the signature
+		wantErr    bool
+	}{
+		{name: "no builder", opts: PlaceOrderOptions{}, wantFee: "0.0005", wantSigned: "0.0005"},
+		{name: "taker with builder", opts: PlaceOrderOptions{BuilderFee: &builderFee, BuilderID: &builderID}, wantFee: "0.0005", wantSigned: "0.0006"},
+		{name: "post-only with builder", opts: PlaceOrderOptions{PostOnly: &postOnly, BuilderFee: &builderFee, BuilderID: &builderID}, wantFee: "0.0002", wantSigned: "0.0003"},
+		{name: "reduce-only with builder", opts: PlaceOrderOptions{ReduceOnly: &reduceOnly, BuilderFee: &builderFee, BuilderID: &builderID}, wantFee: "0.0005", wantSigned: "0.0006"},
+		{
+			name:       "legs with builder",
+			opts:       PlaceOrderOptions{BuilderFee: &builderFee, BuilderID: &builderID, TpSlType: &orderType, TakeProfit: tpSlLeg("55000", "54900")},
+			wantFee:    "0.0005",
+			wantSigned: "0.0006",
+		},
+		{name: "fee without id", opts: PlaceOrderOptions{BuilderFee: &builderFee}, wantErr: true},
+		{name: "id without fee", opts: PlaceOrderOptions{BuilderID: &builderID}, wantErr: true},
+		{name: "negative fee", opts: PlaceOrderOptions{BuilderFee: &negativeFee, BuilderID: &builderID}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := signingMarket()
+			qty, price := decimal.RequireFromString("0.01"), decimal.RequireFromString("50000")
+			tt.opts.ExpireTime = &testExpiry
+			req, err := CreateOrder(account, market, qty, price, "BUY", &tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !req.Fee.Equal(decimal.RequireFromString(tt.wantFee)) {
+				t.Errorf("fee = %s, want %s", req.Fee, tt.wantFee)
+			}
+			if tt.opts.BuilderFee != nil && (req.BuilderFee != builderFee.String() || req.BuilderID != builderID) {
+				t.Errorf("builder fee %s id %d, want %s and %d", req.BuilderFee, req.BuilderID, builderFee, builderID)
+			}
+			if req.ReduceOnly != (tt.opts.ReduceOnly != nil) {
+				t.Errorf("reduceOnly = %v", req.ReduceOnly)
+			}
+
+			signed := decimal.RequireFromString(tt.wantSigned)
+			verifySettlement(t, account, market, "BUY", qty, price, signed, req.Nonce, req.ExpiryEpochMillis, &req.Settlement)
+			if req.TakeProfit != nil {
+				verifySettlement(t, account, market, "SELL", qty, decimal.RequireFromString(req.TakeProfit.Price),
+					user.DefaultFees.TakerFeeRate.Add(builderFee), req.Nonce, req.ExpiryEpochMillis, req.TakeProfit.Settlement)
+			}
+		})
+	}
+}
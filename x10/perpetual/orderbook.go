@@ -0,0 +1,375 @@
+package perpetual
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients/public"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients/stream"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/shopspring/decimal"
+)
+
+// LocalOrderBookOptions contains optional parameters for NewLocalOrderBook
+type LocalOrderBookOptions struct {
+	BestBidChange func(info.OrderBookEntry) // called when the best bid price or size changes
+	BestAskChange func(info.OrderBookEntry) // called when the best ask price or size changes
+}
+
+// LocalOrderBook is an order book maintained locally from the orderbooks stream.
+// It is seeded from PublicClient.GetOrderBook, applies DELTA messages with sequence
+// validation and re-snapshots automatically whenever a sequence gap is detected.
+// This is the Go equivalent of Python's perpetual/orderbook.py OrderBook.
+type LocalOrderBook struct {
+	market string
+	client *public.PublicClient
+	opts   LocalOrderBookOptions
+
+	mu      sync.RWMutex
+	bids    []info.OrderBookEntry // sorted best (highest) first
+	asks    []info.OrderBookEntry // sorted best (lowest) first
+	lastSeq int64
+	synced  bool // false until a stream SNAPSHOT has been applied on the current connection
+	resyncs int
+	err     error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewLocalOrderBook seeds a book for market from the REST snapshot and starts following the stream.
+// The client must have streaming enabled. Call Close to stop the book.
+func NewLocalOrderBook(ctx context.Context, client *public.PublicClient, market string, opts *LocalOrderBookOptions) (*LocalOrderBook, error) {
+	if client == nil {
+		return nil, fmt.Errorf("public client is required")
+	}
+	if market == "" {
+		return nil, fmt.Errorf("market is required")
+	}
+	if client.Stream() == nil {
+		return nil, fmt.Errorf("streaming is not enabled on this client")
+	}
+	if opts == nil {
+		opts = &LocalOrderBookOptions{}
+	}
+
+	book := &LocalOrderBook{
+		market: market,
+		client: client,
+		opts:   *opts,
+		done:   make(chan struct{}),
+	}
+
+	if err := book.seed(ctx); err != nil {
+		return nil, err
+	}
+
+	// The stream outlives ctx; it runs until Close is called.
+	runCtx, cancel := context.WithCancel(context.Background())
+	sub, err := client.Stream().SubscribeToOrderBooks(runCtx, market)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	book.cancel = cancel
+
+	go book.run(runCtx, sub)
+	return book, nil
+}
+
+// seed replaces the book content with the REST order book snapshot.
+func (b *LocalOrderBook) seed(ctx context.Context) error {
+	snapshot, err := b.client.GetOrderBook(ctx, b.market)
+	if err != nil {
+		return fmt.Errorf("failed to seed order book for %s: %w", b.market, err)
+	}
+
+	b.mu.Lock()
+	b.reset(*snapshot)
+	b.lastSeq = 0
+	b.synced = false
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *LocalOrderBook) run(ctx context.Context, sub *stream.Subscription[info.OrderBook]) {
+	defer close(b.done)
+
+	for {
+		gap := b.consume(ctx, sub)
+		sub.Close()
+		if !gap {
+			if err := sub.Err(); err != nil {
+				b.setErr(err)
+			}
+			return
+		}
+
+		// Sequence gap: deltas were lost, so rebuild from a fresh snapshot and a fresh subscription.
+		if err := b.seed(ctx); err != nil {
+			b.setErr(err)
+			return
+		}
+		var err error
+		sub, err = b.client.Stream().SubscribeToOrderBooks(ctx, b.market)
+		if err != nil {
+			b.setErr(err)
+			return
+		}
+
+		b.mu.Lock()
+		b.resyncs++
+		b.mu.Unlock()
+	}
+}
+
+// consume applies stream messages until the subscription ends (false) or a sequence gap is detected (true).
+func (b *LocalOrderBook) consume(ctx context.Context, sub *stream.Subscription[info.OrderBook]) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case msg, ok := <-sub.C:
+			if !ok {
+				return false
+			}
+			if !b.apply(msg) {
+				return true
+			}
+		}
+	}
+}
+
+// apply applies a single stream message and reports false when it reveals a sequence gap.
+func (b *LocalOrderBook) apply(msg stream.Message[info.OrderBook]) bool {
+	b.mu.Lock()
+
+	switch msg.Type {
+	case stream.MessageTypeGap:
+		// The connection was re-established; the new connection starts with a SNAPSHOT.
+		b.synced = false
+		b.lastSeq = 0
+		b.mu.Unlock()
+		return true
+
+	case stream.MessageTypeSnapshot:
+		b.reset(msg.Data)
+		b.lastSeq = msg.Seq
+		b.synced = true
+		bestBid, bestAsk := b.best()
+		b.mu.Unlock()
+		b.notify(bestBid, bestAsk)
+		return true
+
+	case stream.MessageTypeDelta:
+		if !b.synced {
+			b.mu.Unlock()
+			return true
+		}
+		if msg.Seq != b.lastSeq+1 {
+			b.mu.Unlock()
+			return false
+		}
+		b.lastSeq = msg.Seq
+
+		bidBefore, askBefore := b.best()
+		b.bids = applyDelta(b.bids, msg.Data.Bid, true)
+		b.asks = applyDelta(b.asks, msg.Data.Ask, false)
+		bidAfter, askAfter := b.best()
+		b.mu.Unlock()
+
+		if !sameEntry(bidBefore, bidAfter) && bidAfter != nil && b.opts.BestBidChange != nil {
+			b.opts.BestBidChange(*bidAfter)
+		}
+		if !sameEntry(askBefore, askAfter) && askAfter != nil && b.opts.BestAskChange != nil {
+			b.opts.BestAskChange(*askAfter)
+		}
+		return true
+	}
+
+	b.mu.Unlock()
+	return true
+}
+
+func (b *LocalOrderBook) notify(bestBid, bestAsk *info.OrderBookEntry) {
+	if bestBid != nil && b.opts.BestBidChange != nil {
+		b.opts.BestBidChange(*bestBid)
+	}
+	if bestAsk != nil && b.opts.BestAskChange != nil {
+		b.opts.BestAskChange(*bestAsk)
+	}
+}
+
+// reset replaces both sides with a full snapshot. Caller must hold the lock.
+func (b *LocalOrderBook) reset(snapshot info.OrderBook) {
+	b.bids = applyDelta(nil, snapshot.Bid, true)
+	b.asks = applyDelta(nil, snapshot.Ask, false)
+}
+
+// best returns copies of the best bid and ask. Caller must hold the lock.
+func (b *LocalOrderBook) best() (*info.OrderBookEntry, *info.OrderBookEntry) {
+	var bid, ask *info.OrderBookEntry
+	if len(b.bids) > 0 {
+		e := b.bids[0]
+		bid = &e
+	}
+	if len(b.asks) > 0 {
+		e := b.asks[0]
+		ask = &e
+	}
+	return bid, ask
+}
+
+func sameEntry(a, b *info.OrderBookEntry) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Price.Equal(b.Price) && a.Qty.Equal(b.Qty)
+}
+
+// applyDelta adds each entry's quantity to the level at its price, inserting new levels
+// and dropping levels whose quantity reaches zero. Levels stay sorted best first.
+func applyDelta(levels []info.OrderBookEntry, delta []info.OrderBookEntry, descending bool) []info.OrderBookEntry {
+	for _, d := range delta {
+		i := sort.Search(len(levels), func(i int) bool {
+			if descending {
+				return levels[i].Price.LessThanOrEqual(d.Price)
+			}
+			return levels[i].Price.GreaterThanOrEqual(d.Price)
+		})
+
+		if i < len(levels) && levels[i].Price.Equal(d.Price) {
+			levels[i].Qty = levels[i].Qty.Add(d.Qty)
+			if !levels[i].Qty.IsPositive() {
+				levels = append(levels[:i], levels[i+1:]...)
+			}
+			continue
+		}
+		if !d.Qty.IsPositive() {
+			continue
+		}
+
+		levels = append(levels, info.OrderBookEntry{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = info.OrderBookEntry{Price: d.Price, Qty: d.Qty}
+	}
+	return levels
+}
+
+func (b *LocalOrderBook) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Market returns the market this book follows.
+func (b *LocalOrderBook) Market() string {
+	return b.market
+}
+
+// BestBid returns the highest bid, or false when the bid side is empty.
+func (b *LocalOrderBook) BestBid() (info.OrderBookEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 {
+		return info.OrderBookEntry{}, false
+	}
+	return b.bids[0], true
+}
+
+// BestAsk returns the lowest ask, or false when the ask side is empty.
+func (b *LocalOrderBook) BestAsk() (info.OrderBookEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.asks) == 0 {
+		return info.OrderBookEntry{}, false
+	}
+	return b.asks[0], true
+}
+
+// Depth returns a copy of the top levels of each side, best first. levels <= 0 returns the whole book.
+func (b *LocalOrderBook) Depth(levels int) info.OrderBook {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return info.OrderBook{
+		Market: b.market,
+		Bid:    topLevels(b.bids, levels),
+		Ask:    topLevels(b.asks, levels),
+	}
+}
+
+// Snapshot returns a copy of the whole book.
+func (b *LocalOrderBook) Snapshot() info.OrderBook {
+	return b.Depth(0)
+}
+
+// CumulativeVolume returns the total quantity available to a taker order on side within the top levels:
+// "BUY" sums the asks, "SELL" the bids, as in PriceImpactQty. levels <= 0 sums the whole side.
+func (b *LocalOrderBook) CumulativeVolume(side string, levels int) decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var entries []info.OrderBookEntry
+	switch side {
+	case "BUY":
+		entries = b.asks
+	case "SELL":
+		entries = b.bids
+	default:
+		return decimal.Zero
+	}
+
+	total := decimal.Zero
+	for _, e := range topLevels(entries, levels) {
+		total = total.Add(e.Qty)
+	}
+	return total
+}
+
+// Resyncs returns how many times the book was rebuilt after a sequence gap.
+func (b *LocalOrderBook) Resyncs() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.resyncs
+}
+
+// Err returns the error that stopped the book from updating, if any.
+func (b *LocalOrderBook) Err() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.err
+}
+
+// Close stops following the stream. The last known state remains readable.
+func (b *LocalOrderBook) Close() error {
+	b.cancel()
+	<-b.done
+	return nil
+}
+
+func topLevels(entries []info.OrderBookEntry, levels int) []info.OrderBookEntry {
+	if levels <= 0 || levels > len(entries) {
+		levels = len(entries)
+	}
+	out := make([]info.OrderBookEntry, levels)
+	copy(out, entries[:levels])
+	return out
+}
+
+// PriceImpactQty computes the fill of a market order for qty against the current book state.
+// See info.OrderBook.PriceImpactQty.
+func (b *LocalOrderBook) PriceImpactQty(qty decimal.Decimal, side string) *info.PriceImpact {
+	snapshot := b.Snapshot()
+	return snapshot.PriceImpactQty(qty, side)
+}
+
+// PriceImpactNotional computes the fill of a market order spending notional against the current book state.
+// See info.OrderBook.PriceImpactNotional.
+func (b *LocalOrderBook) PriceImpactNotional(notional decimal.Decimal, side string) *info.PriceImpact {
+	snapshot := b.Snapshot()
+	return snapshot.PriceImpactNotional(notional, side)
+}
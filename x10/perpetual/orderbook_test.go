@@ -0,0 +1,271 @@
+package perpetual
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients/public"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/clients/stream"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/shopspring/decimal"
+)
+
+func entry(price, qty string) info.OrderBookEntry {
+	return info.OrderBookEntry{Price: decimal.RequireFromString(price), Qty: decimal.RequireFromString(qty)}
+}
+
+func bookMessage(msgType string, seq int64, bid, ask []info.OrderBookEntry) stream.Message[info.OrderBook] {
+	return stream.Message[info.OrderBook]{
+		Type: msgType,
+		Seq:  seq,
+		Data: info.OrderBook{Market: "BTC-USD", Bid: bid, Ask: ask},
+	}
+}
+
+func TestLocalOrderBookApplySequence(t *testing.T) {
+	book := &LocalOrderBook{market: "BTC-USD"}
+
+	// Deltas before the stream snapshot are ignored
+	if !book.apply(bookMessage(stream.MessageTypeDelta, 1, []info.OrderBookEntry{entry("50", "1")}, nil)) {
+		t.Fatal("delta before snapshot reported a gap")
+	}
+	if _, ok := book.BestBid(); ok {
+		t.Fatal("delta before snapshot was applied")
+	}
+
+	book.apply(bookMessage(stream.MessageTypeSnapshot, 1,
+		[]info.OrderBookEntry{entry("100", "1"), entry("99", "2")},
+		[]info.OrderBookEntry{entry("101", "1"), entry("102", "3")}))
+
+	if !book.apply(bookMessage(stream.MessageTypeDelta, 2,
+		[]info.OrderBookEntry{entry("100", "-1"), entry("99.5", "4")},
+		[]info.OrderBookEntry{entry("101", "2")})) {
+		t.Fatal("consecutive delta reported a gap")
+	}
+
+	if bid, _ := book.BestBid(); !bid.Price.Equal(decimal.RequireFromString("99.5")) || !bid.Qty.Equal(decimal.RequireFromString("4")) {
+		t.Errorf("best bid = %s@%s, want 4@99.5", bid.Qty, bid.Price)
+	}
+	if ask, _ := book.BestAsk(); !ask.Qty.Equal(decimal.RequireFromString("3")) {
+		t.Errorf("best ask qty = %s, want 3", ask.Qty)
+	}
+
+	// Seq 3 is missing
+	if book.apply(bookMessage(stream.MessageTypeDelta, 4, nil, []info.OrderBookEntry{entry("101", "-3")})) {
+		t.Fatal("sequence gap not detected")
+	}
+	if ask, _ := book.BestAsk(); !ask.Price.Equal(decimal.RequireFromString("101")) {
+		t.Errorf("delta after a gap was applied: best ask %s", ask.Price)
+	}
+
+	// A reconnect waits for the next snapshot before applying deltas again
+	book.apply(stream.Message[info.OrderBook]{Type: stream.MessageTypeGap})
+	if !book.apply(bookMessage(stream.MessageTypeDelta, 5, nil, []info.OrderBookEntry{entry("101", "-3")})) {
+		t.Fatal("delta after reconnect reported a gap")
+	}
+	if ask, _ := book.BestAsk(); !ask.Price.Equal(decimal.RequireFromString("101")) {
+		t.Errorf("delta after reconnect was applied before the snapshot: best ask %s", ask.Price)
+	}
+}
+
+func TestLocalOrderBookCumulativeVolume(t *testing.T) {
+	book := &LocalOrderBook{market: "BTC-USD"}
+	book.apply(bookMessage(stream.MessageTypeSnapshot, 1,
+		[]info.OrderBookEntry{entry("100", "1"), entry("99", "2")},
+		[]info.OrderBookEntry{entry("101", "3"), entry("102", "4"), entry("103", "5")}))
+
+	tests := []struct {
+		side   string
+		levels int
+		want   string
+	}{
+		{"BUY", 2, "7"},
+		{"BUY", 0, "12"},
+		{"SELL", 1, "1"},
+		{"SELL", 0, "3"},
+		{"LONG", 0, "0"},
+	}
+	for _, tt := range tests {
+		if got := book.CumulativeVolume(tt.side, tt.levels); !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("CumulativeVolume(%s, %d) = %s, want %s", tt.side, tt.levels, got, tt.want)
+		}
+	}
+}
+
+func TestNewLocalOrderBookRequiresMarket(t *testing.T) {
+	client := public.NewPublicClient(x10.Testnet(), true)
+	if _, err := NewLocalOrderBook(context.Background(), client, "", nil); err == nil {
+		t.Fatal("expected an error for an empty market")
+	}
+}
+
+// orderBookServer serves the REST order book and one scripted stream per connection.
+type orderBookServer struct {
+	mu        sync.Mutex
+	snapshots int
+	streams   [][]string // messages sent on each successive stream connection; dropStream closes it
+}
+
+// dropStream in a scripted stream makes the server drop the connection instead of waiting for the client.
+const dropStream = "DROP"
+
+func (s *orderBookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/info/markets/BTC-USD/orderbook":
+		s.mu.Lock()
+		s.snapshots++
+		s.mu.Unlock()
+		fmt.Fprint(w, `{"status":"OK","data":{"market":"BTC-USD","bid":[{"qty":"1","price":"90"}],"ask":[{"qty":"1","price":"110"}]}}`)
+
+	case "/orderbooks/BTC-USD":
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		s.mu.Lock()
+		var messages []string
+		if len(s.streams) > 0 {
+			messages, s.streams = s.streams[0], s.streams[1:]
+		}
+		s.mu.Unlock()
+
+		for _, m := range messages {
+			if m == dropStream {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestLocalOrderBookResnapshotsAfterGap(t *testing.T) {
+	srv := &orderBookServer{streams: [][]string{
+		{
+			`{"type":"SNAPSHOT","seq":1,"data":{"m":"BTC-USD","b":[{"p":"100","q":"1"}],"a":[{"p":"101","q":"1"}]}}`,
+			`{"type":"DELTA","seq":2,"data":{"m":"BTC-USD","b":[{"p":"100","q":"1"}],"a":[]}}`,
+			`{"type":"DELTA","seq":4,"data":{"m":"BTC-USD","b":[{"p":"100","q":"5"}],"a":[]}}`,
+		},
+		{
+			`{"type":"SNAPSHOT","seq":1,"data":{"m":"BTC-USD","b":[{"p":"99","q":"3"}],"a":[{"p":"101","q":"2"}]}}`,
+		},
+	}}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := public.NewPublicClient(x10.Testnet(), true,
+		clients.WithBaseURL(server.URL),
+		clients.WithStreamURL("ws"+strings.TrimPrefix(server.URL, "http")),
+	)
+	client.SetRateLimiter(nil)
+
+	book, err := NewLocalOrderBook(context.Background(), client, "BTC-USD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer book.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		bid, _ := book.BestBid()
+		if book.Resyncs() == 1 && bid.Price.Equal(decimal.RequireFromString("99")) {
+			break
+		}
+		if err := book.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("book did not resync: resyncs=%d best bid=%s@%s", book.Resyncs(), bid.Qty, bid.Price)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	srv.mu.Lock()
+	snapshots := srv.snapshots
+	srv.mu.Unlock()
+	if snapshots != 2 {
+		t.Errorf("REST snapshots = %d, want 2", snapshots)
+	}
+	if ask, _ := book.BestAsk(); !ask.Qty.Equal(decimal.RequireFromString("2")) {
+		t.Errorf("best ask qty = %s, want 2", ask.Qty)
+	}
+}
+
+func TestLocalOrderBookFollowsReconnect(t *testing.T) {
+	srv := &orderBookServer{streams: [][]string{
+		{
+			`{"type":"SNAPSHOT","seq":1,"data":{"m":"BTC-USD","b":[{"p":"100","q":"1"}],"a":[{"p":"101","q":"1"}]}}`,
+			`{"type":"DELTA","seq":2,"data":{"m":"BTC-USD","b":[{"p":"100","q":"2"}],"a":[]}}`,
+			dropStream,
+		},
+		{
+			// Deltas before the new connection's snapshot belong to the old sequence and are skipped
+			`{"type":"DELTA","seq":9,"data":{"m":"BTC-USD","b":[{"p":"100","q":"7"}],"a":[]}}`,
+			`{"type":"SNAPSHOT","seq":1,"data":{"m":"BTC-USD","b":[{"p":"98","q":"4"}],"a":[{"p":"102","q":"1"}]}}`,
+			`{"type":"DELTA","seq":2,"data":{"m":"BTC-USD","b":[],"a":[{"p":"102","q":"3"}]}}`,
+		},
+	}}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	client := public.NewPublicClient(x10.Testnet(), true,
+		clients.WithBaseURL(server.URL),
+		clients.WithStreamURL("ws"+strings.TrimPrefix(server.URL, "http")),
+	)
+	client.SetRateLimiter(nil)
+	client.Stream().SetReconnectPolicy(stream.ReconnectPolicy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1})
+
+	book, err := NewLocalOrderBook(context.Background(), client, "BTC-USD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer book.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ask, _ := book.BestAsk()
+		// The snapshot's 1@102 plus the delta's 3
+		if ask.Price.Equal(decimal.RequireFromString("102")) && ask.Qty.Equal(decimal.RequireFromString("4")) {
+			break
+		}
+		if err := book.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("book did not follow the reconnect: best ask=%s@%s", ask.Qty, ask.Price)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if bid, _ := book.BestBid(); !bid.Price.Equal(decimal.RequireFromString("98")) || !bid.Qty.Equal(decimal.RequireFromString("4")) {
+		t.Errorf("best bid = %s@%s, want 4@98 from the new snapshot", bid.Qty, bid.Price)
+	}
+	// The stream re-seeds the book after a reconnect, so no REST snapshot or new subscription is needed
+	srv.mu.Lock()
+	snapshots := srv.snapshots
+	srv.mu.Unlock()
+	if snapshots != 1 || book.Resyncs() != 0 {
+		t.Errorf("REST snapshots = %d, resyncs = %d; want 1, 0", snapshots, book.Resyncs())
+	}
+}
@@ -0,0 +1,126 @@
+package perpetual
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// TpSlLeg is a take-profit or stop-loss attached to an order via PlaceOrderOptions.
+// The leg closes the order's quantity on the opposite side once TriggerPrice is hit.
+type TpSlLeg struct {
+	TriggerPrice     decimal.Decimal
+	TriggerPriceType string          // LAST | INDEX | MARK
+	Price            decimal.Decimal // limit price, or the protective worst price when PriceType is MARKET
+	PriceType        string          // LIMIT | MARKET
+}
+
+func (l *TpSlLeg) validate(name string) error {
+	if !l.TriggerPrice.IsPositive() {
+		return fmt.Errorf("%s trigger price must be positive", name)
+	}
+	if !l.Price.IsPositive() {
+		return fmt.Errorf("%s price must be positive", name)
+	}
+	switch l.TriggerPriceType {
+	case "LAST", "INDEX", "MARK":
+	default:
+		return fmt.Errorf("invalid %s trigger price type: %s", name, l.TriggerPriceType)
+	}
+	switch l.PriceType {
+	case "LIMIT", "MARKET":
+	default:
+		return fmt.Errorf("invalid %s price type: %s", name, l.PriceType)
+	}
+	return nil
+}
+
+// attachTpSl adds the take-profit and stop-loss legs from opts to req. Each leg is an order on the
+// opposite side for the same quantity, sharing the parent's nonce and expiry, and carries its own settlement.
+// Leg prices are rounded with opts.AutoRound like the parent's, and must otherwise be multiples of MinPriceChange.
+func attachTpSl(
+	req *user.CreateOrderRequest,
+	market *info.Market,
+	syntheticAmount decimal.Decimal,
+	fees user.TradingFee,
+	account *starknet.StarknetPerpetualAccount,
+	opts *PlaceOrderOptions,
+) error {
+	if opts.TakeProfit == nil && opts.StopLoss == nil {
+		if opts.TpSlType != nil {
+			return fmt.Errorf("tpSlType is set but neither take profit nor stop loss is provided")
+		}
+		return nil
+	}
+
+	tpSlType := getStringValue(opts.TpSlType)
+	switch tpSlType {
+	case "ORDER", "POSITION":
+	default:
+		return fmt.Errorf("tpSlType must be ORDER or POSITION, got %q", tpSlType)
+	}
+
+	// The legs are signed with the parent's nonce, as in the Python SDK: the exchange ties them to the
+	// parent order, and their hashes still differ from it because the side and amounts differ.
+	nonce, err := strconv.ParseInt(req.Nonce, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid order nonce: %w", err)
+	}
+	expireTime := time.UnixMilli(req.ExpiryEpochMillis)
+	isBuyingSynthetic := req.Side != "BUY"
+	legSide := "SELL"
+	if isBuyingSynthetic {
+		legSide = "BUY"
+	}
+
+	buildLeg := func(name string, leg *TpSlLeg) (*user.TpslConfig, error) {
+		if err := leg.validate(name); err != nil {
+			return nil, err
+		}
+
+		triggerPrice, price := leg.TriggerPrice, leg.Price
+		if opts.AutoRound != nil && *opts.AutoRound {
+			// The limit is rounded in the leg's favour like the parent price; the trigger has no favourable side
+			_, price = RoundOrder(market, syntheticAmount, price, legSide)
+			triggerPrice = roundToNearestStep(triggerPrice, market.TradingConfig.MinPriceChange)
+		}
+		for _, p := range []decimal.Decimal{triggerPrice, price} {
+			if !isMultiple(p, market.TradingConfig.MinPriceChange) {
+				return nil, &ValidationError{Reason: ReasonInvalidPriceTick, Market: market.Name, Value: p, Limit: market.TradingConfig.MinPriceChange}
+			}
+		}
+
+		amounts := models.NewStarkOrderAmounts(market, syntheticAmount, price, totalFeeRate(fees.TakerFeeRate, opts.BuilderFee), isBuyingSynthetic)
+		_, settlement, err := settle(amounts, isBuyingSynthetic, &expireTime, nonce, account.Vault, account.Signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to settle %s: %w", name, err)
+		}
+
+		return &user.TpslConfig{
+			TriggerPrice:     triggerPrice.String(),
+			TriggerPriceType: leg.TriggerPriceType,
+			Price:            price.String(),
+			PriceType:        leg.PriceType,
+			Settlement:       &settlement,
+		}, nil
+	}
+
+	if opts.TakeProfit != nil {
+		if req.TakeProfit, err = buildLeg("take profit", opts.TakeProfit); err != nil {
+			return err
+		}
+	}
+	if opts.StopLoss != nil {
+		if req.StopLoss, err = buildLeg("stop loss", opts.StopLoss); err != nil {
+			return err
+		}
+	}
+	req.TpSlType = tpSlType
+	return nil
+}
@@ -0,0 +1,123 @@
+package perpetual
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/shopspring/decimal"
+)
+
+func tpSlLeg(trigger, price string) *TpSlLeg {
+	return &TpSlLeg{
+		TriggerPrice:     decimal.RequireFromString(trigger),
+		TriggerPriceType: "MARK",
+		Price:            decimal.RequireFromString(price),
+		PriceType:        "LIMIT",
+	}
+}
+
+func TestAttachTpSl(t *testing.T) {
+	account := testAccount(t, 10002)
+	autoRound := true
+	orderType, positionType, invalidType := "ORDER", "POSITION", "BRACKET"
+
+	tests := []struct {
+		name       string
+		side       string
+		opts       PlaceOrderOptions
+		wantTP     [2]string // rounded trigger and limit price of the take profit, if any
+		wantSL     [2]string
+		wantErr    error // nil with wantFailed means any error
+		wantFailed bool
+	}{
+		{
+			name:   "legs on a buy",
+			side:   "BUY",
+			opts:   PlaceOrderOptions{TpSlType: &orderType, TakeProfit: tpSlLeg("55000", "54900"), StopLoss: tpSlLeg("45000", "44900")},
+			wantTP: [2]string{"55000", "54900"},
+			wantSL: [2]string{"45000", "44900"},
+		},
+		{
+			name:   "stop loss only on a sell",
+			side:   "SELL",
+			opts:   PlaceOrderOptions{TpSlType: &positionType, StopLoss: tpSlLeg("55000", "55100")},
+			wantSL: [2]string{"55000", "55100"},
+		},
+		{
+			name:   "auto-rounded sell leg of a buy",
+			side:   "BUY",
+			opts:   PlaceOrderOptions{AutoRound: &autoRound, TpSlType: &orderType, TakeProfit: tpSlLeg("55000.6", "54900.2")},
+			wantTP: [2]string{"55001", "54901"},
+		},
+		{
+			name:   "auto-rounded buy leg of a sell",
+			side:   "SELL",
+			opts:   PlaceOrderOptions{AutoRound: &autoRound, TpSlType: &orderType, TakeProfit: tpSlLeg("45000.4", "45100.8")},
+			wantTP: [2]string{"45000", "45100"},
+		},
+		{
+			name:    "leg price off the tick",
+			side:    "BUY",
+			opts:    PlaceOrderOptions{TpSlType: &orderType, TakeProfit: tpSlLeg("55000", "54900.5")},
+			wantErr: ErrInvalidPriceTick,
+		},
+		{
+			name:    "trigger price off the tick",
+			side:    "BUY",
+			opts:    PlaceOrderOptions{TpSlType: &orderType, StopLoss: tpSlLeg("45000.5", "44900")},
+			wantErr: ErrInvalidPriceTick,
+		},
+		{name: "legs without a type", side: "BUY", opts: PlaceOrderOptions{TakeProfit: tpSlLeg("55000", "54900")}, wantFailed: true},
+		{name: "invalid type", side: "BUY", opts: PlaceOrderOptions{TpSlType: &invalidType, TakeProfit: tpSlLeg("55000", "54900")}, wantFailed: true},
+		{name: "type without legs", side: "BUY", opts: PlaceOrderOptions{TpSlType: &orderType}, wantFailed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := signingMarket()
+			qty, price := decimal.RequireFromString("0.01"), decimal.RequireFromString("50000")
+			tt.opts.ExpireTime = &testExpiry
+			req, err := CreateOrder(account, market, qty, price, tt.side, &tt.opts)
+			if tt.wantErr != nil || tt.wantFailed {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			legSide := "SELL"
+			if tt.side == "SELL" {
+				legSide = "BUY"
+			}
+			check := func(name string, leg *user.TpslConfig, want [2]string) {
+				if want[0] == "" {
+					if leg != nil {
+						t.Errorf("unexpected %s leg", name)
+					}
+					return
+				}
+				if leg == nil {
+					t.Fatalf("%s leg is missing", name)
+				}
+				if leg.TriggerPrice != want[0] || leg.Price != want[1] {
+					t.Errorf("%s trigger %s price %s, want %s and %s", name, leg.TriggerPrice, leg.Price, want[0], want[1])
+				}
+				// Legs reuse the parent's nonce and expiry and always sign the taker fee
+				verifySettlement(t, account, market, legSide, qty, decimal.RequireFromString(want[1]),
+					user.DefaultFees.TakerFeeRate, req.Nonce, req.ExpiryEpochMillis, leg.Settlement)
+			}
+			check("take profit", req.TakeProfit, tt.wantTP)
+			check("stop loss", req.StopLoss, tt.wantSL)
+			if req.TpSlType != *tt.opts.TpSlType {
+				t.Errorf("tpSlType = %s, want %s", req.TpSlType, *tt.opts.TpSlType)
+			}
+		})
+	}
+}
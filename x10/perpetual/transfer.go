@@ -0,0 +1,104 @@
+package perpetual
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// TransferExpiry is how long a signed transfer stays valid.
+const TransferExpiry = 7 * 24 * time.Hour
+
+// CreateTransfer creates a signed transfer of amount collateral from the account's vault to toVault,
+// whose Stark public key is toL2Key. No fee is charged for transfers.
+func CreateTransfer(account *starknet.StarknetPerpetualAccount, cfg *x10.Config, toVault int, toL2Key string, amount decimal.Decimal) (*user.TransferRequest, error) {
+	nonce, err := starknet.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	return createTransfer(account, cfg, toVault, toL2Key, amount, nonce, time.Now())
+}
+
+// createTransfer signs a transfer with nonce that expires TransferExpiry after now.
+func createTransfer(account *starknet.StarknetPerpetualAccount, cfg *x10.Config, toVault int, toL2Key string, amount decimal.Decimal, nonce int64, now time.Time) (*user.TransferRequest, error) {
+	if account == nil {
+		return nil, fmt.Errorf("stark account is not set")
+	}
+	assetID, starkAmount, err := collateralAmount(cfg, amount)
+	if err != nil {
+		return nil, err
+	}
+	receiverPublicKey, ok := new(big.Int).SetString(toL2Key, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid receiver public key: %s", toL2Key)
+	}
+
+	expiration := starknet.ExpirationTimestamp(now.UTC().Add(TransferExpiry))
+
+	transferHash, err := starknet.HashTransfer(
+		assetID,
+		big.NewInt(0),
+		receiverPublicKey,
+		int64(account.Vault),
+		int64(toVault),
+		int64(account.Vault),
+		nonce,
+		starkAmount,
+		big.NewInt(0),
+		expiration,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transfer hash: %w", err)
+	}
+
+	r, s, err := account.Sign(transferHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transfer: %w", err)
+	}
+
+	return &user.TransferRequest{
+		FromVault: account.Vault,
+		ToVault:   toVault,
+		Amount:    amount,
+		Settlement: user.TransferSettlement{
+			Amount:              starkAmount.Int64(),
+			AssetID:             fmt.Sprintf("0x%x", assetID),
+			ExpirationTimestamp: expiration,
+			Nonce:               nonce,
+			ReceiverPositionID:  toVault,
+			ReceiverPublicKey:   fmt.Sprintf("0x%x", receiverPublicKey),
+			SenderPositionID:    account.Vault,
+			SenderPublicKey:     fmt.Sprintf("0x%x", account.PublicKey),
+			Signature: user.SettlementSignature{
+				R: fmt.Sprintf("0x%x", r),
+				S: fmt.Sprintf("0x%x", s),
+			},
+		},
+		TransferredAsset: cfg.CollateralAssetOnChainID,
+	}, nil
+}
+
+// collateralAmount returns the collateral asset ID from cfg and amount converted to Stark units.
+// amount must be positive and must not have more decimals than the collateral.
+func collateralAmount(cfg *x10.Config, amount decimal.Decimal) (*big.Int, *big.Int, error) {
+	if cfg == nil || cfg.CollateralAssetOnChainID == "" {
+		return nil, nil, fmt.Errorf("collateral asset is not configured")
+	}
+	assetID, ok := new(big.Int).SetString(cfg.CollateralAssetOnChainID, 0)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid collateral asset ID: %s", cfg.CollateralAssetOnChainID)
+	}
+	if !amount.IsPositive() {
+		return nil, nil, fmt.Errorf("amount must be positive")
+	}
+	scaled := amount.Shift(cfg.CollateralDecimals)
+	if !scaled.IsInteger() {
+		return nil, nil, fmt.Errorf("amount %s has more than %d decimals", amount, cfg.CollateralDecimals)
+	}
+	return assetID, scaled.BigInt(), nil
+}
@@ -0,0 +1,84 @@
+package perpetual
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/shopspring/decimal"
+)
+
+// Fixtures of python_sdk/tests/perpetual/test_transfer_object.py and test_withdrawal_object.py.
+const testNonce = 1473459052
+
+var frozenTime = time.Date(2024, 1, 5, 1, 8, 56, 860694000, time.UTC)
+
+// assertJSON compares the JSON encoding of got with want, ignoring key order.
+func assertJSON(t *testing.T, got any, want string) {
+	t.Helper()
+	encoded, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotValue, wantValue any
+	if err := json.Unmarshal(encoded, &gotValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("JSON mismatch\n got: %s\nwant: %s", encoded, want)
+	}
+}
+
+func TestCreateTransferMatchesPythonSDK(t *testing.T) {
+	transfer, err := createTransfer(
+		testAccount(t, 10001),
+		x10.Testnet(),
+		10002,
+		"0x3895139a98a6168dc8b0db251bcd0e6dcf97fd1e96f7a87d9bd3f341753a844",
+		decimal.RequireFromString("1.1"),
+		testNonce,
+		frozenTime,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The Python test reports another key as the sender; the signed fields are identical.
+	assertJSON(t, transfer, `{
+		"fromVault": 10001,
+		"toVault": 10002,
+		"amount": "1.1",
+		"transferredAsset": "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+		"settlement": {
+			"amount": 1100000,
+			"assetId": "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+			"expirationTimestamp": 473954,
+			"nonce": 1473459052,
+			"receiverPositionId": 10002,
+			"receiverPublicKey": "0x3895139a98a6168dc8b0db251bcd0e6dcf97fd1e96f7a87d9bd3f341753a844",
+			"senderPositionId": 10001,
+			"senderPublicKey": "0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466",
+			"signature": {
+				"r": "0x6840d40d8a7e190caa9bf823e9d8ee08462148b30cfdaff306302d686b22fa9",
+				"s": "0x4bd52731c5549f4e0781e8ffa7c5aea9be0aa01ca502a50ca7fc7cc46ccdb2f"
+			}
+		}
+	}`)
+}
+
+func TestCreateTransferRejectsInvalidAmount(t *testing.T) {
+	account := testAccount(t, 10001)
+	for _, amount := range []string{"0", "-1", "1.0000001"} {
+		if _, err := createTransfer(account, x10.Testnet(), 10002, "0x1", decimal.RequireFromString(amount), testNonce, frozenTime); err == nil {
+			t.Errorf("amount %s: expected an error", amount)
+		}
+	}
+	if _, err := createTransfer(account, x10.Testnet(), 10002, "not a key", decimal.RequireFromString("1"), testNonce, frozenTime); err == nil {
+		t.Error("expected an error for an invalid receiver key")
+	}
+}
@@ -0,0 +1,113 @@
+package perpetual
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// TwapParams describes how the exchange slices a TWAP order.
+type TwapParams struct {
+	Duration  time.Duration // total execution time
+	Frequency time.Duration // interval between slices
+	Randomize bool          // let the exchange randomize slice timing and size
+}
+
+func (t TwapParams) validate() error {
+	if t.Duration <= 0 || t.Duration%time.Second != 0 {
+		return fmt.Errorf("twap duration must be a positive whole number of seconds")
+	}
+	if t.Frequency <= 0 || t.Frequency%time.Second != 0 {
+		return fmt.Errorf("twap frequency must be a positive whole number of seconds")
+	}
+	if t.Frequency > t.Duration {
+		return fmt.Errorf("twap frequency %s is longer than duration %s", t.Frequency, t.Duration)
+	}
+	return nil
+}
+
+// CreateTwapOrder creates a TWAP parent order that the exchange executes in slices over twap.Duration.
+// price is the protective worst price for every slice; the signature covers the full quantity.
+// The order expiry defaults to twap.Duration plus one hour and must not be earlier than the end of execution.
+func CreateTwapOrder(
+	account *starknet.StarknetPerpetualAccount,
+	market *info.Market,
+	amountOfSynthetic decimal.Decimal,
+	price decimal.Decimal,
+	side string,
+	twap TwapParams,
+	opts *PlaceOrderOptions,
+) (*user.CreateOrderRequest, error) {
+	if market == nil {
+		return nil, fmt.Errorf("market is required")
+	}
+
+	if account == nil || account.Signer == nil {
+		return nil, fmt.Errorf("account with a signer is required")
+	}
+
+	if err := twap.validate(); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &PlaceOrderOptions{}
+	}
+	if opts.PostOnly != nil && *opts.PostOnly {
+		return nil, fmt.Errorf("twap orders cannot be post-only")
+	}
+	if opts.TakeProfit != nil || opts.StopLoss != nil {
+		return nil, fmt.Errorf("twap orders cannot carry take profit or stop loss legs")
+	}
+
+	end := time.Now().Add(twap.Duration)
+	expireTime := opts.ExpireTime
+	if expireTime == nil {
+		defaultExpire := end.Add(time.Hour)
+		expireTime = &defaultExpire
+	} else if expireTime.Before(end) {
+		return nil, fmt.Errorf("expire time %s is before the end of twap execution", expireTime)
+	}
+
+	amountOfSynthetic, price, err := prepareOrder(market, "TWAP", amountOfSynthetic, price, side, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := orderFees(account, market, opts)
+
+	req, err := createOrder(
+		market,
+		"TWAP",
+		amountOfSynthetic,
+		price,
+		side,
+		account.Vault,
+		fees,
+		account.Signer,
+		false,
+		expireTime,
+		false,
+		opts.PreviousOrderID,
+		opts.OrderExternalID,
+		opts.TimeInForce,
+		opts.SelfTradeProtectionLevel,
+		opts.ReduceOnly != nil && *opts.ReduceOnly,
+		opts.BuilderFee,
+		opts.BuilderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Twap = &user.TwapConfig{
+		DurationSeconds:  int64(twap.Duration / time.Second),
+		FrequencySeconds: int64(twap.Frequency / time.Second),
+		Randomize:        twap.Randomize,
+	}
+	return req, nil
+}
@@ -0,0 +1,70 @@
+package perpetual
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/shopspring/decimal"
+)
+
+func TestCreateTwapOrder(t *testing.T) {
+	account := testAccount(t, 10002)
+	hourly := TwapParams{Duration: time.Hour, Frequency: 30 * time.Second, Randomize: true}
+	postOnly := true
+	soon := time.Now().Add(30 * time.Minute)
+	orderType := "ORDER"
+
+	tests := []struct {
+		name    string
+		twap    TwapParams
+		opts    *PlaceOrderOptions
+		wantErr bool
+	}{
+		{name: "explicit expiry", twap: hourly, opts: &PlaceOrderOptions{ExpireTime: &testExpiry}},
+		{name: "default expiry", twap: hourly},
+		{name: "expiry before the end of execution", twap: hourly, opts: &PlaceOrderOptions{ExpireTime: &soon}, wantErr: true},
+		{name: "post-only", twap: hourly, opts: &PlaceOrderOptions{PostOnly: &postOnly}, wantErr: true},
+		{name: "with TP/SL legs", twap: hourly, opts: &PlaceOrderOptions{TpSlType: &orderType, TakeProfit: tpSlLeg("55000", "54900")}, wantErr: true},
+		{name: "no duration", twap: TwapParams{Frequency: 30 * time.Second}, wantErr: true},
+		{name: "fractional frequency", twap: TwapParams{Duration: time.Hour, Frequency: 1500 * time.Millisecond}, wantErr: true},
+		{name: "frequency longer than duration", twap: TwapParams{Duration: time.Minute, Frequency: time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := signingMarket()
+			qty, price := decimal.RequireFromString("0.01"), decimal.RequireFromString("50000")
+			start := time.Now()
+			req, err := CreateTwapOrder(account, market, qty, price, "BUY", tt.twap, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := user.TwapConfig{
+				DurationSeconds:  int64(tt.twap.Duration / time.Second),
+				FrequencySeconds: int64(tt.twap.Frequency / time.Second),
+				Randomize:        tt.twap.Randomize,
+			}
+			if req.Type != "TWAP" || req.Twap == nil || *req.Twap != want {
+				t.Errorf("got type %s, twap %+v; want TWAP with %+v", req.Type, req.Twap, want)
+			}
+
+			if tt.opts == nil {
+				// Execution time plus one hour
+				expiry := time.UnixMilli(req.ExpiryEpochMillis)
+				if earliest := start.Add(2 * time.Hour).Truncate(time.Millisecond); expiry.Before(earliest) || expiry.After(time.Now().Add(2*time.Hour)) {
+					t.Errorf("expiry = %s, want two hours from now", expiry)
+				}
+				return
+			}
+			verifySettlement(t, account, market, "BUY", qty, price, user.DefaultFees.TakerFeeRate, req.Nonce, req.ExpiryEpochMillis, &req.Settlement)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package perpetual
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/shopspring/decimal"
+)
+
+// Reasons reported by ValidationError.
+const (
+	ReasonOrderTooSmall       = "ORDER_TOO_SMALL"
+	ReasonInvalidQtyStep      = "INVALID_QTY_STEP"
+	ReasonInvalidPriceTick    = "INVALID_PRICE_TICK"
+	ReasonPriceTooFarFromMark = "PRICE_TOO_FAR_FROM_MARK"
+	ReasonOrderValueTooLarge  = "ORDER_VALUE_TOO_LARGE"
+	ReasonLeverageTooHigh     = "LEVERAGE_TOO_HIGH"
+	ReasonTooManyOrders       = "TOO_MANY_ORDERS"
+)
+
+// Sentinel errors for use with errors.Is; use errors.As with *ValidationError for the details.
+var (
+	ErrOrderTooSmall       = &ValidationError{Reason: ReasonOrderTooSmall}
+	ErrInvalidQtyStep      = &ValidationError{Reason: ReasonInvalidQtyStep}
+	ErrInvalidPriceTick    = &ValidationError{Reason: ReasonInvalidPriceTick}
+	ErrPriceTooFarFromMark = &ValidationError{Reason: ReasonPriceTooFarFromMark}
+	ErrOrderValueTooLarge  = &ValidationError{Reason: ReasonOrderValueTooLarge}
+	ErrLeverageTooHigh     = &ValidationError{Reason: ReasonLeverageTooHigh}
+	ErrTooManyOrders       = &ValidationError{Reason: ReasonTooManyOrders}
+)
+
+// ValidationError is returned when an order or a leverage update breaks a market's TradingConfig rules.
+// It is detected before the order is signed, so no request is sent to the exchange.
+type ValidationError struct {
+	Reason string
+	Market string
+	Value  decimal.Decimal // the offending qty, price, order value, leverage or open orders count
+	Limit  decimal.Decimal // the bound or increment it was checked against
+}
+
+func (e *ValidationError) Error() string {
+	switch e.Reason {
+	case ReasonOrderTooSmall:
+		return fmt.Sprintf("order qty %s is below min order size %s for %s", e.Value, e.Limit, e.Market)
+	case ReasonInvalidQtyStep:
+		return fmt.Sprintf("order qty %s is not a multiple of %s for %s", e.Value, e.Limit, e.Market)
+	case ReasonInvalidPriceTick:
+		return fmt.Sprintf("order price %s is not a multiple of %s for %s", e.Value, e.Limit, e.Market)
+	case ReasonPriceTooFarFromMark:
+		return fmt.Sprintf("order price %s is beyond the allowed limit %s for %s", e.Value, e.Limit, e.Market)
+	case ReasonOrderValueTooLarge:
+		return fmt.Sprintf("order value %s exceeds max order value %s for %s", e.Value, e.Limit, e.Market)
+	case ReasonLeverageTooHigh:
+		return fmt.Sprintf("leverage %s exceeds max leverage %s for %s", e.Value, e.Limit, e.Market)
+	case ReasonTooManyOrders:
+		return fmt.Sprintf("%s open orders already reach the max number of orders %s for %s", e.Value, e.Limit, e.Market)
+	}
+	return fmt.Sprintf("invalid order for %s: %s", e.Market, e.Reason)
+}
+
+// Is matches any ValidationError with the same Reason, so errors.Is works against the sentinels.
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	return ok && t.Reason == e.Reason
+}
+
+// ValidateOrder checks an order against the market's TradingConfig: minimum size, qty and price
+// increments, max order value (MaxMarketOrderValue for MARKET orders, MaxLimitOrderValue otherwise),
+// MaxNumOrders against the openOrders already on the market and, when markPrice is positive,
+// LimitPriceCap for buys and LimitPriceFloor for sells.
+func ValidateOrder(market *info.Market, orderType string, qty decimal.Decimal, price decimal.Decimal, side string, markPrice decimal.Decimal, openOrders int) error {
+	if market == nil {
+		return fmt.Errorf("market is required")
+	}
+	if side != "BUY" && side != "SELL" {
+		return fmt.Errorf("invalid order side: %s", side)
+	}
+	if !qty.IsPositive() || !price.IsPositive() {
+		return fmt.Errorf("order qty and price must be positive")
+	}
+
+	cfg := market.TradingConfig
+	if qty.LessThan(cfg.MinOrderSize) {
+		return &ValidationError{Reason: ReasonOrderTooSmall, Market: market.Name, Value: qty, Limit: cfg.MinOrderSize}
+	}
+	if !isMultiple(qty, cfg.MinOrderSizeChange) {
+		return &ValidationError{Reason: ReasonInvalidQtyStep, Market: market.Name, Value: qty, Limit: cfg.MinOrderSizeChange}
+	}
+	if !isMultiple(price, cfg.MinPriceChange) {
+		return &ValidationError{Reason: ReasonInvalidPriceTick, Market: market.Name, Value: price, Limit: cfg.MinPriceChange}
+	}
+
+	maxValue := cfg.MaxLimitOrderValue
+	if orderType == "MARKET" {
+		maxValue = cfg.MaxMarketOrderValue
+	}
+	if value := qty.Mul(price); maxValue.IsPositive() && value.GreaterThan(maxValue) {
+		return &ValidationError{Reason: ReasonOrderValueTooLarge, Market: market.Name, Value: value, Limit: maxValue}
+	}
+
+	if maxOrders, err := strconv.Atoi(cfg.MaxNumOrders); err == nil && maxOrders > 0 && openOrders >= maxOrders {
+		return &ValidationError{Reason: ReasonTooManyOrders, Market: market.Name, Value: decimal.NewFromInt(int64(openOrders)), Limit: decimal.NewFromInt(int64(maxOrders))}
+	}
+
+	if markPrice.IsPositive() {
+		one := decimal.NewFromInt(1)
+		if side == "BUY" && cfg.LimitPriceCap.IsPositive() {
+			if limit := markPrice.Mul(one.Add(cfg.LimitPriceCap)); price.GreaterThan(limit) {
+				return &ValidationError{Reason: ReasonPriceTooFarFromMark, Market: market.Name, Value: price, Limit: limit}
+			}
+		}
+		if side == "SELL" && cfg.LimitPriceFloor.IsPositive() {
+			if limit := markPrice.Mul(one.Sub(cfg.LimitPriceFloor)); price.LessThan(limit) {
+				return &ValidationError{Reason: ReasonPriceTooFarFromMark, Market: market.Name, Value: price, Limit: limit}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateLeverage checks leverage against the max leverage of the market's risk factor tier for a
+// position worth positionValue (see info.TradingConfig.MaxLeverageForValue).
+func ValidateLeverage(market *info.Market, leverage decimal.Decimal, positionValue decimal.Decimal) error {
+	if market == nil {
+		return fmt.Errorf("market is required")
+	}
+	if !leverage.IsPositive() {
+		return fmt.Errorf("leverage must be positive")
+	}
+
+	// A zero max is only a limit when it comes from the tiers: the position is above all of them
+	cfg := market.TradingConfig
+	maxLeverage := cfg.MaxLeverageForValue(positionValue.Abs())
+	if (maxLeverage.IsPositive() || len(cfg.RiskFactorConfig) > 0) && leverage.GreaterThan(maxLeverage) {
+		return &ValidationError{Reason: ReasonLeverageTooHigh, Market: market.Name, Value: leverage, Limit: maxLeverage}
+	}
+	return nil
+}
+
+// RoundOrder rounds qty down to MinOrderSizeChange and price to MinPriceChange in the order's favour
+// (down for buys, up for sells), so the rounded order never trades more or at a worse price than requested.
+func RoundOrder(market *info.Market, qty decimal.Decimal, price decimal.Decimal, side string) (decimal.Decimal, decimal.Decimal) {
+	cfg := market.TradingConfig
+	return roundToStep(qty, cfg.MinOrderSizeChange, false), roundToStep(price, cfg.MinPriceChange, side == "SELL")
+}
+
+// prepareOrder applies opt-in auto-rounding and validates the order before it is signed.
+func prepareOrder(market *info.Market, orderType string, qty decimal.Decimal, price decimal.Decimal, side string, opts *PlaceOrderOptions) (decimal.Decimal, decimal.Decimal, error) {
+	if opts.AutoRound != nil && *opts.AutoRound {
+		qty, price = RoundOrder(market, qty, price, side)
+	}
+
+	markPrice := decimal.Zero
+	if opts.MarkPrice != nil {
+		markPrice = *opts.MarkPrice
+	}
+	// A replacement cancels the order it replaces, so it does not add to the open orders
+	openOrders := 0
+	if opts.OpenOrders != nil && opts.PreviousOrderID == nil {
+		openOrders = *opts.OpenOrders
+	}
+	if err := ValidateOrder(market, orderType, qty, price, side, markPrice, openOrders); err != nil {
+		return qty, price, err
+	}
+	return qty, price, nil
+}
+
+// isMultiple reports whether value is a whole multiple of step. A non-positive step accepts any value.
+func isMultiple(value, step decimal.Decimal) bool {
+	if !step.IsPositive() {
+		return true
+	}
+	return value.Mod(step).IsZero()
+}
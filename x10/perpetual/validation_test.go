@@ -0,0 +1,115 @@
+package perpetual
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/shopspring/decimal"
+)
+
+func validationMarket() *info.Market {
+	return &info.Market{
+		Name: "BTC-USD",
+		TradingConfig: info.TradingConfig{
+			MinOrderSize:        decimal.RequireFromString("0.0001"),
+			MinOrderSizeChange:  decimal.RequireFromString("0.0001"),
+			MinPriceChange:      decimal.RequireFromString("1"),
+			MaxMarketOrderValue: decimal.RequireFromString("1000000"),
+			MaxLimitOrderValue:  decimal.RequireFromString("5000000"),
+			MaxNumOrders:        "200",
+			LimitPriceCap:       decimal.RequireFromString("0.05"),
+			LimitPriceFloor:     decimal.RequireFromString("0.05"),
+		},
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		qty        string
+		price      string
+		side       string
+		markPrice  string
+		openOrders int
+		want       error
+	}{
+		{name: "valid", qty: "0.01", price: "50000", side: "BUY", markPrice: "50000", openOrders: 199},
+		{name: "too small", qty: "0.00005", price: "50000", side: "BUY", markPrice: "0", want: ErrOrderTooSmall},
+		{name: "qty step", qty: "0.00015", price: "50000", side: "BUY", markPrice: "0", want: ErrInvalidQtyStep},
+		{name: "price tick", qty: "0.01", price: "50000.5", side: "BUY", markPrice: "0", want: ErrInvalidPriceTick},
+		{name: "order value", qty: "200", price: "50000", side: "BUY", markPrice: "0", want: ErrOrderValueTooLarge},
+		{name: "too many orders", qty: "0.01", price: "50000", side: "BUY", markPrice: "0", openOrders: 200, want: ErrTooManyOrders},
+		{name: "buy above cap", qty: "0.01", price: "52501", side: "BUY", markPrice: "50000", want: ErrPriceTooFarFromMark},
+		{name: "buy at cap", qty: "0.01", price: "52500", side: "BUY", markPrice: "50000"},
+		{name: "sell below floor", qty: "0.01", price: "47499", side: "SELL", markPrice: "50000", want: ErrPriceTooFarFromMark},
+		{name: "no mark price skips the cap", qty: "0.01", price: "80000", side: "BUY", markPrice: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOrder(validationMarket(), "LIMIT",
+				decimal.RequireFromString(tt.qty), decimal.RequireFromString(tt.price), tt.side,
+				decimal.RequireFromString(tt.markPrice), tt.openOrders)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareOrderSkipsOrderCountForReplacements(t *testing.T) {
+	openOrders := 200
+	previousID := "previous"
+	qty, price := decimal.RequireFromString("0.01"), decimal.RequireFromString("50000")
+
+	if _, _, err := prepareOrder(validationMarket(), "LIMIT", qty, price, "BUY", &PlaceOrderOptions{OpenOrders: &openOrders}); !errors.Is(err, ErrTooManyOrders) {
+		t.Fatalf("err = %v, want %v", err, ErrTooManyOrders)
+	}
+	opts := &PlaceOrderOptions{OpenOrders: &openOrders, PreviousOrderID: &previousID}
+	if _, _, err := prepareOrder(validationMarket(), "LIMIT", qty, price, "BUY", opts); err != nil {
+		t.Fatalf("unexpected error for a replacement: %v", err)
+	}
+}
+
+func TestValidateLeverage(t *testing.T) {
+	market := validationMarket()
+	market.TradingConfig.MaxLeverage = decimal.RequireFromString("50")
+	market.TradingConfig.RiskFactorConfig = []info.RiskFactorConfig{
+		{UpperBound: decimal.RequireFromString("400000"), RiskFactor: decimal.RequireFromString("0.02")},
+		{UpperBound: decimal.RequireFromString("800000"), RiskFactor: decimal.RequireFromString("0.04")},
+	}
+
+	tests := []struct {
+		name          string
+		leverage      string
+		positionValue string
+		want          error
+	}{
+		{name: "first tier", leverage: "50", positionValue: "100000"},
+		{name: "second tier", leverage: "25", positionValue: "-500000"},
+		{name: "above second tier max", leverage: "26", positionValue: "500000", want: ErrLeverageTooHigh},
+		{name: "above every tier", leverage: "1", positionValue: "900000", want: ErrLeverageTooHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLeverage(market, decimal.RequireFromString(tt.leverage), decimal.RequireFromString(tt.positionValue))
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package perpetual
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+// WithdrawalExpiry is how long a signed slow withdrawal stays valid.
+const WithdrawalExpiry = 15 * 24 * time.Hour
+
+// CreateWithdrawal creates a signed slow withdrawal of amount collateral from the account's vault
+// to the Ethereum address ethAddress. description is optional.
+func CreateWithdrawal(account *starknet.StarknetPerpetualAccount, cfg *x10.Config, amount decimal.Decimal, ethAddress string, description string) (*user.SlowWithdrawalRequest, error) {
+	nonce, err := starknet.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	return createWithdrawal(account, cfg, amount, ethAddress, description, nonce, time.Now())
+}
+
+// createWithdrawal signs a withdrawal with nonce that expires WithdrawalExpiry after now.
+func createWithdrawal(account *starknet.StarknetPerpetualAccount, cfg *x10.Config, amount decimal.Decimal, ethAddress string, description string, nonce int64, now time.Time) (*user.SlowWithdrawalRequest, error) {
+	if account == nil {
+		return nil, fmt.Errorf("stark account is not set")
+	}
+	assetID, starkAmount, err := collateralAmount(cfg, amount)
+	if err != nil {
+		return nil, err
+	}
+	address, ok := new(big.Int).SetString(strings.TrimPrefix(strings.ToLower(ethAddress), "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid eth address: %s", ethAddress)
+	}
+
+	expiration := starknet.ExpirationTimestamp(now.UTC().Add(WithdrawalExpiry))
+
+	withdrawalHash, err := starknet.HashWithdrawal(assetID, int64(account.Vault), address, nonce, expiration, starkAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create withdrawal hash: %w", err)
+	}
+
+	r, s, err := account.Sign(withdrawalHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign withdrawal: %w", err)
+	}
+
+	return &user.SlowWithdrawalRequest{
+		Amount: amount,
+		Settlement: user.WithdrawalSettlement{
+			Amount:              starkAmount.Int64(),
+			CollateralAssetID:   fmt.Sprintf("0x%x", assetID),
+			EthAddress:          fmt.Sprintf("0x%x", address),
+			ExpirationTimestamp: expiration,
+			Nonce:               nonce,
+			PositionID:          account.Vault,
+			PublicKey:           fmt.Sprintf("0x%x", account.PublicKey),
+			Signature: user.SettlementSignature{
+				R: fmt.Sprintf("0x%x", r),
+				S: fmt.Sprintf("0x%x", s),
+			},
+		},
+		Description: description,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package perpetual
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/utils/starknet"
+	"github.com/shopspring/decimal"
+)
+
+func TestCreateWithdrawalMatchesPythonSDK(t *testing.T) {
+	withdrawal, err := createWithdrawal(
+		testAccount(t, 10002),
+		x10.Testnet(),
+		decimal.RequireFromString("1.1"),
+		"0x6c5a62e584D0289def8Fe3c9C8194a07246a2C52",
+		"withdraw my gains",
+		testNonce,
+		frozenTime,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertJSON(t, withdrawal, `{
+		"amount": "1.1",
+		"settlement": {
+			"amount": 1100000,
+			"collateralAssetId": "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+			"ethAddress": "0x6c5a62e584d0289def8fe3c9c8194a07246a2c52",
+			"expirationTimestamp": 474146,
+			"nonce": 1473459052,
+			"positionId": 10002,
+			"publicKey": "0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466",
+			"signature": {
+				"r": "0x3f3aa8b0c2f2a8953aef42dd79d7c1003a98df241b7a989bb0ed122ae9e99dd",
+				"s": "0x789b22f03b13df2e95d5bffd472f1c8abb325291a142e55b7bd61a6cc998b46"
+			}
+		},
+		"description": "withdraw my gains"
+	}`)
+}
+
+func TestWithdrawalExpirationRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want int64
+	}{
+		// now + 15 days + 14 days of buffer, rounded up to the next hour
+		{name: "python fixture", now: frozenTime, want: 474146},
+		{name: "whole hour is kept", now: time.Date(2024, 1, 5, 1, 0, 0, 0, time.UTC), want: 474145},
+		{name: "one microsecond past the hour", now: time.Date(2024, 1, 5, 1, 0, 0, 1000, time.UTC), want: 474146},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := starknet.ExpirationTimestamp(tt.now.Add(WithdrawalExpiry)); got != tt.want {
+				t.Errorf("ExpirationTimestamp = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateWithdrawalRejectsInvalidAddress(t *testing.T) {
+	if _, err := createWithdrawal(testAccount(t, 10002), x10.Testnet(), decimal.RequireFromString("1"), "0xnothex", "", testNonce, frozenTime); err == nil {
+		t.Error("expected an error for an invalid eth address")
+	}
+}
@@ -13,9 +13,11 @@ import (
 )
 
 const (
-	OPLimitOrderWithFees = 3
-	HoursInDay           = 24
-	SecondsInHour        = 60 * 60
+	OPLimitOrderWithFees  = 3
+	OPTransfer            = 4
+	OPWithdrawalToAddress = 7
+	HoursInDay            = 24
+	SecondsInHour         = 60 * 60
 )
 
 // todo: add godocs
@@ -41,8 +43,7 @@ func HashOrder(amounts models.StarkOrderAmounts, isBuyingSynthetic bool, expireT
 	collateralStarkBig := collateralStark.Value
 	feeStarkBig := feeStark.Value
 
-	expireTimeWithBuffer := expireTime.AddDate(0, 0, 14)
-	expireTimeInHours := int64(math.Ceil(float64(expireTimeWithBuffer.Unix()) / float64(SecondsInHour)))
+	expireTimeInHours := ExpirationTimestamp(*expireTime)
 
 	positionID := int64(vaultID)
 
@@ -134,6 +135,149 @@ func getLimitOrderMsg(
 	return curve.Pedersen(msg, fpm1), nil
 }
 
+// ExpirationTimestamp converts an expiry time to the signed expiration: hours since the epoch,
+// rounded up, with the 14 day buffer the exchange expects added. Like the Python SDK it rounds
+// with microsecond precision, so a fraction of a second past the hour counts as the next hour.
+func ExpirationTimestamp(expireTime time.Time) int64 {
+	expireTimeWithBuffer := expireTime.AddDate(0, 0, 14)
+	const microsInHour = SecondsInHour * int64(time.Second/time.Microsecond)
+	return int64(math.Ceil(float64(expireTimeWithBuffer.UnixMicro()) / float64(microsInHour)))
+}
+
+// HashTransfer returns the message hash signed for a transfer of amount (in Stark units) of assetID
+// from senderPositionID to receiverPositionID, owned by receiverPublicKey.
+// expirationTimestamp is in hours, see ExpirationTimestamp.
+func HashTransfer(
+	assetID, assetIDFee, receiverPublicKey *big.Int,
+	senderPositionID, receiverPositionID, srcFeePositionID int64,
+	nonce int64,
+	amount, maxAmountFee *big.Int,
+	expirationTimestamp int64,
+) (*felt.Felt, error) {
+	bounds := []struct {
+		name  string
+		value *big.Int
+		bits  uint
+	}{
+		{"amount", amount, 64},
+		{"asset id", assetID, 250},
+		{"fee asset id", assetIDFee, 250},
+		{"expiration timestamp", big.NewInt(expirationTimestamp), 32},
+		{"max amount fee", maxAmountFee, 64},
+		{"nonce", big.NewInt(nonce), 32},
+		{"receiver position id", big.NewInt(receiverPositionID), 64},
+		{"receiver public key", receiverPublicKey, 251},
+		{"sender position id", big.NewInt(senderPositionID), 64},
+		{"fee position id", big.NewInt(srcFeePositionID), 64},
+	}
+	for _, b := range bounds {
+		if err := checkBound(b.name, b.value, b.bits); err != nil {
+			return nil, err
+		}
+	}
+
+	fasset, err := bigIntToFelt(assetID)
+	if err != nil {
+		return nil, err
+	}
+	ffee, err := bigIntToFelt(assetIDFee)
+	if err != nil {
+		return nil, err
+	}
+	msg := curve.Pedersen(fasset, ffee)
+
+	freceiver, err := bigIntToFelt(receiverPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	msg = curve.Pedersen(msg, freceiver)
+
+	packedMessage0 := big.NewInt(senderPositionID)
+	packedMessage0.Lsh(packedMessage0, 64)                             // packed_message0 * 2^64
+	packedMessage0.Add(packedMessage0, big.NewInt(receiverPositionID)) // + receiver_position_id
+	packedMessage0.Lsh(packedMessage0, 64)                             // * 2^64
+	packedMessage0.Add(packedMessage0, big.NewInt(srcFeePositionID))   // + src_fee_position_id
+	packedMessage0.Lsh(packedMessage0, 32)                             // * 2^32
+	packedMessage0.Add(packedMessage0, big.NewInt(nonce))              // + nonce
+
+	fpm0, err := bigIntToFelt(packedMessage0)
+	if err != nil {
+		return nil, err
+	}
+	msg = curve.Pedersen(msg, fpm0)
+
+	packedMessage1 := big.NewInt(OPTransfer)
+	packedMessage1.Lsh(packedMessage1, 64)                              // packed_message1 * 2^64
+	packedMessage1.Add(packedMessage1, amount)                          // + amount
+	packedMessage1.Lsh(packedMessage1, 64)                              // * 2^64
+	packedMessage1.Add(packedMessage1, maxAmountFee)                    // + max_amount_fee
+	packedMessage1.Lsh(packedMessage1, 32)                              // * 2^32
+	packedMessage1.Add(packedMessage1, big.NewInt(expirationTimestamp)) // + expiration_timestamp
+	packedMessage1.Lsh(packedMessage1, 81)                              // * 2^81 (Padding)
+
+	fpm1, err := bigIntToFelt(packedMessage1)
+	if err != nil {
+		return nil, err
+	}
+	return curve.Pedersen(msg, fpm1), nil
+}
+
+// HashWithdrawal returns the message hash signed for a withdrawal of amount (in Stark units) of the
+// collateral asset from positionID to the Ethereum address ethAddress.
+// expirationTimestamp is in hours, see ExpirationTimestamp.
+func HashWithdrawal(assetIDCollateral *big.Int, positionID int64, ethAddress *big.Int, nonce int64, expirationTimestamp int64, amount *big.Int) (*felt.Felt, error) {
+	bounds := []struct {
+		name  string
+		value *big.Int
+		bits  uint
+	}{
+		{"collateral asset id", assetIDCollateral, 250},
+		{"nonce", big.NewInt(nonce), 32},
+		{"position id", big.NewInt(positionID), 64},
+		{"expiration timestamp", big.NewInt(expirationTimestamp), 32},
+		{"amount", amount, 64},
+		{"eth address", ethAddress, 160},
+	}
+	for _, b := range bounds {
+		if err := checkBound(b.name, b.value, b.bits); err != nil {
+			return nil, err
+		}
+	}
+
+	packedMessage := big.NewInt(OPWithdrawalToAddress)
+	packedMessage.Lsh(packedMessage, 64)                              // packed_message * 2^64
+	packedMessage.Add(packedMessage, big.NewInt(positionID))          // + position_id
+	packedMessage.Lsh(packedMessage, 32)                              // * 2^32
+	packedMessage.Add(packedMessage, big.NewInt(nonce))               // + nonce
+	packedMessage.Lsh(packedMessage, 64)                              // * 2^64
+	packedMessage.Add(packedMessage, amount)                          // + amount
+	packedMessage.Lsh(packedMessage, 32)                              // * 2^32
+	packedMessage.Add(packedMessage, big.NewInt(expirationTimestamp)) // + expiration_timestamp
+	packedMessage.Lsh(packedMessage, 49)                              // * 2^49 (Padding)
+
+	fasset, err := bigIntToFelt(assetIDCollateral)
+	if err != nil {
+		return nil, err
+	}
+	faddress, err := bigIntToFelt(ethAddress)
+	if err != nil {
+		return nil, err
+	}
+	fpm, err := bigIntToFelt(packedMessage)
+	if err != nil {
+		return nil, err
+	}
+	return curve.Pedersen(curve.Pedersen(fasset, faddress), fpm), nil
+}
+
+// checkBound verifies 0 <= value < 2^bits for a field packed into a hashed message.
+func checkBound(name string, value *big.Int, bits uint) error {
+	if value == nil || value.Sign() < 0 || value.BitLen() > int(bits) {
+		return fmt.Errorf("%s is out of range", name)
+	}
+	return nil
+}
+
 // GenerateNonce returns a uniformly random nonce in the range [0, 2^31).
 // This is suitable for order uniqueness and replay protection.
 func GenerateNonce() (int64, error) {
@@ -0,0 +1,146 @@
+package starknet
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
+	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/info"
+	"github.com/shopspring/decimal"
+)
+
+// testNonce is FROZEN_NONCE in the Python SDK tests.
+const testNonce = 1473459052
+
+// testMarket is the BTC-USD market of python_sdk/tests/fixtures/markets.py.
+func testMarket() *info.Market {
+	return &info.Market{
+		Name:                     "BTC-USD",
+		AssetName:                "BTC",
+		AssetPrecision:           5,
+		CollateralAssetName:      "USD",
+		CollateralAssetPrecision: 6,
+		Active:                   true,
+		L2Config: info.L2Config{
+			Type:                 "STARKX",
+			CollateralID:         "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+			CollateralResolution: 1000000,
+			SyntheticID:          "0x4254432d3600000000000000000000",
+			SyntheticResolution:  1000000,
+		},
+	}
+}
+
+func TestHashOrderMatchesPythonSDK(t *testing.T) {
+	tests := []struct {
+		name         string
+		side         string
+		expiryMillis int64
+		hash         string
+	}{
+		{"test_create_sell_order_with_default_expiration", "SELL", 1704445737000, orderVectors[0].hash},
+		{"test_create_sell_order", "SELL", 1705626536860, orderVectors[1].hash},
+		{"test_create_buy_order", "BUY", 1705626536860, orderVectors[2].hash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isBuying := tt.side == "BUY"
+			amounts := models.NewStarkOrderAmounts(
+				testMarket(),
+				decimal.RequireFromString("0.00100000"),
+				decimal.RequireFromString("43445.11680000"),
+				decimal.RequireFromString("0.0005"),
+				isBuying,
+			)
+			expireTime := time.UnixMilli(tt.expiryMillis).UTC()
+
+			hash, err := HashOrder(amounts, isBuying, &expireTime, testNonce, 10002)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hash.BigInt(new(big.Int)).String(); got != tt.hash {
+				t.Errorf("hash = %s, want %s", got, tt.hash)
+			}
+		})
+	}
+}
+
+func TestExpirationTimestamp(t *testing.T) {
+	// 2024-01-05 01:08:56.860694 UTC plus 7 days, as in test_transfer_object.py
+	at := time.Date(2024, 1, 12, 1, 8, 56, 860694000, time.UTC)
+	if got := ExpirationTimestamp(at); got != 473954 {
+		t.Errorf("ExpirationTimestamp = %d, want 473954", got)
+	}
+	// A whole hour is not rounded up
+	at = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got, want := ExpirationTimestamp(at), at.AddDate(0, 0, 14).Unix()/SecondsInHour; got != want {
+		t.Errorf("ExpirationTimestamp = %d, want %d", got, want)
+	}
+}
+
+func TestHashTransferMatchesPythonSDK(t *testing.T) {
+	hash, err := HashTransfer(
+		mustBigInt(t, "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054"),
+		big.NewInt(0),
+		mustBigInt(t, "0x3895139a98a6168dc8b0db251bcd0e6dcf97fd1e96f7a87d9bd3f341753a844"),
+		10001,
+		10002,
+		10001,
+		testNonce,
+		big.NewInt(1100000),
+		big.NewInt(0),
+		473954,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, s, err := Sign(hash.BigInt(new(big.Int)), mustBigInt(t, testPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprintf("0x%x", r), "0x6840d40d8a7e190caa9bf823e9d8ee08462148b30cfdaff306302d686b22fa9"; got != want {
+		t.Errorf("r = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprintf("0x%x", s), "0x4bd52731c5549f4e0781e8ffa7c5aea9be0aa01ca502a50ca7fc7cc46ccdb2f"; got != want {
+		t.Errorf("s = %s, want %s", got, want)
+	}
+}
+
+func TestHashTransferChecksBounds(t *testing.T) {
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 64)
+	if _, err := HashTransfer(big.NewInt(1), big.NewInt(0), big.NewInt(1), 1, 2, 1, testNonce, tooLarge, big.NewInt(0), 473954); err == nil {
+		t.Error("expected an error for an amount of 2^64")
+	}
+	if _, err := HashTransfer(big.NewInt(1), big.NewInt(0), big.NewInt(1), -1, 2, 1, testNonce, big.NewInt(1), big.NewInt(0), 473954); err == nil {
+		t.Error("expected an error for a negative position")
+	}
+}
+
+func TestHashWithdrawalMatchesPythonSDK(t *testing.T) {
+	hash, err := HashWithdrawal(
+		mustBigInt(t, "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054"),
+		10002,
+		mustBigInt(t, "0x6c5a62e584d0289def8fe3c9c8194a07246a2c52"),
+		testNonce,
+		474146,
+		big.NewInt(1100000),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, s, err := Sign(hash.BigInt(new(big.Int)), mustBigInt(t, testPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprintf("0x%x", r), "0x3f3aa8b0c2f2a8953aef42dd79d7c1003a98df241b7a989bb0ed122ae9e99dd"; got != want {
+		t.Errorf("r = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprintf("0x%x", s), "0x789b22f03b13df2e95d5bffd472f1c8abb325291a142e55b7bd61a6cc998b46"; got != want {
+		t.Errorf("s = %s, want %s", got, want)
+	}
+}
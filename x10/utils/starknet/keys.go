@@ -0,0 +1,64 @@
+package starknet
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+
+	felt "github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+)
+
+// GrindKey deterministically derives a key in [0, limit) from keySeed, as StarkWare's grind_key does:
+// sha256(seed || index) is retried with increasing index until it falls below the largest multiple
+// of limit that fits in 256 bits, so the result is uniform.
+func GrindKey(keySeed *big.Int, limit *big.Int) *big.Int {
+	space := new(big.Int).Lsh(big.NewInt(1), 256)
+	maxAllowed := new(big.Int).Sub(space, new(big.Int).Mod(space, limit))
+
+	for index := int64(0); ; index++ {
+		input := append(minimalBytes(keySeed), minimalBytes(big.NewInt(index))...)
+		digest := sha256.Sum256(input)
+		key := new(big.Int).SetBytes(digest[:])
+		if key.Cmp(maxAllowed) < 0 {
+			return key.Mod(key, limit)
+		}
+	}
+}
+
+// minimalBytes encodes x big-endian in as few bytes as possible, with zero encoded as a single byte.
+func minimalBytes(x *big.Int) []byte {
+	if x.Sign() == 0 {
+		return []byte{0}
+	}
+	return x.Bytes()
+}
+
+// PrivateKeyFromEthSignature derives the Stark private key from an Ethereum key-derivation signature
+// (hex r || s || v): the r component is ground into the Stark curve order.
+func PrivateKeyFromEthSignature(ethSignature string) (*big.Int, error) {
+	sig := strings.TrimPrefix(ethSignature, "0x")
+	if len(sig) < 64 {
+		return nil, fmt.Errorf("invalid eth signature: too short")
+	}
+	r, ok := new(big.Int).SetString(sig[:64], 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid eth signature: %s", ethSignature)
+	}
+	return GrindKey(r, ecOrder), nil
+}
+
+// HashL1Address returns the message signed with the Stark key during onboarding to bind the Stark
+// public key l2Key to the Ethereum address l1Address.
+func HashL1Address(l1Address *big.Int, l2Key *big.Int) (*felt.Felt, error) {
+	faddress, err := bigIntToFelt(l1Address)
+	if err != nil {
+		return nil, err
+	}
+	fkey, err := bigIntToFelt(l2Key)
+	if err != nil {
+		return nil, err
+	}
+	return curve.Pedersen(faddress, fkey), nil
+}
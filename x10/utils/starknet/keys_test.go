@@ -0,0 +1,33 @@
+package starknet
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestGrindKey(t *testing.T) {
+	// Vector from the StarkWare key derivation tests
+	seed := mustBigInt(t, "0x86F3E7293141F20A8BAFF320E8EE4ACCB9D4A4BF2B4D295E8CEE784DB46E0519")
+	if got, want := fmt.Sprintf("0x%x", GrindKey(seed, ecOrder)), "0x5c8c8683596c732541a59e03007b2d30dbbbb873556fe65b5fb63c16688f941"; got != want {
+		t.Errorf("GrindKey = %s, want %s", got, want)
+	}
+}
+
+func TestGrindKeyStaysBelowLimit(t *testing.T) {
+	limit := big.NewInt(1000)
+	for seed := int64(0); seed < 50; seed++ {
+		if key := GrindKey(big.NewInt(seed), limit); key.Sign() < 0 || key.Cmp(limit) >= 0 {
+			t.Fatalf("GrindKey(%d) = %s, want in [0, 1000)", seed, key)
+		}
+	}
+}
+
+func TestPrivateKeyFromEthSignatureRejectsInvalidInput(t *testing.T) {
+	for _, invalid := range []string{"", "0x1234", "0x" + strings.Repeat("zz", 64)} {
+		if _, err := PrivateKeyFromEthSignature(invalid); err == nil {
+			t.Errorf("PrivateKeyFromEthSignature(%q): expected an error", invalid)
+		}
+	}
+}
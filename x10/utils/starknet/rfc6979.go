@@ -0,0 +1,142 @@
+package starknet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/NethermindEth/starknet.go/curve"
+)
+
+// ecOrder is the order of the Stark curve generator point (EC_ORDER in the Python SDK).
+var ecOrder, _ = new(big.Int).SetString("800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f", 16)
+
+// maxSignable is 2^251; message hashes, r and w must all be below this bound.
+var maxSignable = new(big.Int).Lsh(big.NewInt(1), 251)
+
+// Sign produces a deterministic Stark ECDSA signature of msgHash using RFC6979 nonce generation.
+// The output matches the vendored Python starkware signature.sign byte for byte, which is what the API expects.
+func Sign(msgHash, privateKey *big.Int) (*big.Int, *big.Int, error) {
+	if msgHash == nil || privateKey == nil {
+		return nil, nil, fmt.Errorf("message hash and private key are required")
+	}
+	if msgHash.Sign() < 0 || msgHash.Cmp(maxSignable) >= 0 {
+		return nil, nil, fmt.Errorf("message hash %s is not signable", msgHash.String())
+	}
+	if privateKey.Sign() <= 0 || privateKey.Cmp(ecOrder) >= 0 {
+		return nil, nil, fmt.Errorf("private key is out of range")
+	}
+
+	var seed *big.Int
+	for {
+		k := generateKRFC6979(msgHash, privateKey, seed)
+		// Bump the seed for the next attempt in case k turns out to be unusable.
+		if seed == nil {
+			seed = big.NewInt(1)
+		} else {
+			seed = new(big.Int).Add(seed, big.NewInt(1))
+		}
+
+		// Unlike classic ECDSA, r is the x coordinate itself and is not reduced mod n.
+		r, _ := curve.PrivateKeyToPoint(k)
+		if r.Sign() <= 0 || r.Cmp(maxSignable) >= 0 {
+			continue
+		}
+
+		// w = k / (msgHash + r*privateKey) mod n
+		denominator := new(big.Int).Mul(r, privateKey)
+		denominator.Add(denominator, msgHash)
+		denominator.Mod(denominator, ecOrder)
+		if denominator.Sign() == 0 {
+			continue
+		}
+
+		w := new(big.Int).ModInverse(denominator, ecOrder)
+		w.Mul(w, k)
+		w.Mod(w, ecOrder)
+		if w.Sign() <= 0 || w.Cmp(maxSignable) >= 0 {
+			continue
+		}
+
+		s := new(big.Int).ModInverse(w, ecOrder)
+		return r, s, nil
+	}
+}
+
+// generateKRFC6979 derives the signing nonce k as described in RFC6979 section 3.2 using HMAC-SHA256.
+// It mirrors starkware's generate_k_rfc6979, including the elliptic.js compatible hash padding.
+func generateKRFC6979(msgHash, privateKey, seed *big.Int) *big.Int {
+	hash := new(big.Int).Set(msgHash)
+	// Pad the message hash for consistency with elliptic.js, but only when it is one nibble short.
+	if bitLen := hash.BitLen(); bitLen >= 248 && bitLen%8 >= 1 && bitLen%8 <= 4 {
+		hash.Lsh(hash, 4)
+	}
+
+	var extraEntropy []byte
+	if seed != nil {
+		extraEntropy = seed.Bytes()
+	}
+
+	qlen := ecOrder.BitLen()
+	rolen := (qlen + 7) / 8
+
+	x := make([]byte, rolen)
+	privateKey.FillBytes(x)
+	h1 := bits2octets(hash.Bytes(), qlen, rolen)
+
+	v := make([]byte, sha256.Size)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k := make([]byte, sha256.Size)
+
+	k = hmacSHA256(k, v, []byte{0x00}, x, h1, extraEntropy)
+	v = hmacSHA256(k, v)
+	k = hmacSHA256(k, v, []byte{0x01}, x, h1, extraEntropy)
+	v = hmacSHA256(k, v)
+
+	for {
+		var t []byte
+		for len(t) < rolen {
+			v = hmacSHA256(k, v)
+			t = append(t, v...)
+		}
+
+		secret := bits2int(t, qlen)
+		if secret.Sign() > 0 && secret.Cmp(ecOrder) < 0 {
+			return secret
+		}
+
+		k = hmacSHA256(k, v, []byte{0x00})
+		v = hmacSHA256(k, v)
+	}
+}
+
+// bits2int converts a byte string to an integer keeping only its leftmost qlen bits.
+func bits2int(data []byte, qlen int) *big.Int {
+	x := new(big.Int).SetBytes(data)
+	if l := len(data) * 8; l > qlen {
+		x.Rsh(x, uint(l-qlen))
+	}
+	return x
+}
+
+// bits2octets reduces the hashed message modulo the curve order and encodes it on rolen bytes.
+func bits2octets(data []byte, qlen, rolen int) []byte {
+	z := bits2int(data, qlen)
+	if z.Cmp(ecOrder) >= 0 {
+		z.Sub(z, ecOrder)
+	}
+	out := make([]byte, rolen)
+	z.FillBytes(out)
+	return out
+}
+
+func hmacSHA256(key []byte, parts ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, p := range parts {
+		mac.Write(p)
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,74 @@
+package starknet
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// Golden vectors from python_sdk/tests/perpetual/test_order_object.py.
+// The order ID in those tests is the order hash, so signing it must reproduce the expected r/s.
+const testPrivateKey = "0x7a7ff6fd3cab02ccdcd4a572563f5976f8976899b03a39773795a3c486d4986"
+
+var orderVectors = []struct {
+	name string
+	hash string
+	r    string
+	s    string
+}{
+	{
+		name: "test_create_sell_order_with_default_expiration",
+		hash: "2096045681239655445582070517240411138302380632690430411530650608228763263945",
+		r:    "0x39ff8493e8e26c9a588a7046e1380b6e1201287a179e10831b7040d3efc26d",
+		s:    "0x5c9acd1879bf8d43e4ccd14648186d2a9edf387fe1b61e491fe0a539de3272b",
+	},
+	{
+		name: "test_create_sell_order",
+		hash: "2656406151911156282898770907232061209407892373872976831396563134482995247110",
+		r:    "0x5766fe0420270feadb55cd6d89cedba0bb8cbd3847fca73d27fe78b0c499b48",
+		s:    "0xc8456b2db2060d25990471f22cae59bed86d51e508812455458f0464cc5867",
+	},
+	{
+		name: "test_create_buy_order",
+		hash: "1166889461421716582054747865777410838520755143669870072976787470981175645302",
+		r:    "0x52a42b6cb980b552c08d5d01b86852b64f7468f5ed7430133f0e2ea1b53df0",
+		s:    "0x67287f8aca9f96bc0fa58e5f0f6875e52f869fc392d912145ff9cb16b73a666",
+	},
+}
+
+func mustBigInt(t *testing.T, value string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		t.Fatalf("invalid integer %q", value)
+	}
+	return n
+}
+
+func TestSignMatchesPythonSDK(t *testing.T) {
+	privateKey := mustBigInt(t, testPrivateKey)
+	for _, v := range orderVectors {
+		t.Run(v.name, func(t *testing.T) {
+			r, s, err := Sign(mustBigInt(t, v.hash), privateKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprintf("0x%x", r); got != v.r {
+				t.Errorf("r = %s, want %s", got, v.r)
+			}
+			if got := fmt.Sprintf("0x%x", s); got != v.s {
+				t.Errorf("s = %s, want %s", got, v.s)
+			}
+		})
+	}
+}
+
+func TestSignRejectsInvalidInput(t *testing.T) {
+	privateKey := mustBigInt(t, testPrivateKey)
+	if _, _, err := Sign(maxSignable, privateKey); err == nil {
+		t.Error("expected an error for a hash of 2^251")
+	}
+	if _, _, err := Sign(big.NewInt(1), ecOrder); err == nil {
+		t.Error("expected an error for a private key equal to the curve order")
+	}
+}
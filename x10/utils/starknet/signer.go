@@ -0,0 +1,212 @@
+package starknet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	felt "github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet.go/curve"
+)
+
+// Signer produces Stark signatures for message hashes (orders, transfers, withdrawals).
+// Implementations may keep the private key in memory or delegate signing to an external process,
+// so the trading process never has to hold the raw key.
+type Signer interface {
+	// PublicKey returns the Stark public key (x coordinate) matching the signing key.
+	PublicKey() *big.Int
+	// Sign returns the r, s components of the signature over msgHash.
+	Sign(msgHash *felt.Felt) (*big.Int, *big.Int, error)
+}
+
+// ContextSigner is a Signer whose signing can be cancelled, e.g. because it waits on another process.
+type ContextSigner interface {
+	Signer
+	// SignContext is Sign bound to ctx: it gives up and returns ctx's error when ctx is done.
+	SignContext(ctx context.Context, msgHash *felt.Felt) (*big.Int, *big.Int, error)
+}
+
+// boundSigner signs through a ContextSigner with a fixed context (see StarknetPerpetualAccount.WithContext).
+type boundSigner struct {
+	ctx    context.Context
+	signer ContextSigner
+}
+
+func (b boundSigner) PublicKey() *big.Int {
+	return b.signer.PublicKey()
+}
+
+func (b boundSigner) Sign(msgHash *felt.Felt) (*big.Int, *big.Int, error) {
+	return b.signer.SignContext(b.ctx, msgHash)
+}
+
+// PrivateKeySigner signs in-process with a Stark private key held in memory.
+type PrivateKeySigner struct {
+	privateKey *big.Int
+	publicKey  *big.Int
+}
+
+// NewPrivateKeySigner creates an in-memory signer. The public key is derived from the private key.
+func NewPrivateKeySigner(privateKey *big.Int) (*PrivateKeySigner, error) {
+	if privateKey == nil || privateKey.Sign() <= 0 || privateKey.Cmp(ecOrder) >= 0 {
+		return nil, fmt.Errorf("private key is out of range")
+	}
+	publicKey, _ := curve.PrivateKeyToPoint(privateKey)
+	return &PrivateKeySigner{
+		privateKey: new(big.Int).Set(privateKey),
+		publicKey:  publicKey,
+	}, nil
+}
+
+// PublicKey returns the Stark public key derived from the private key.
+func (s *PrivateKeySigner) PublicKey() *big.Int {
+	return s.publicKey
+}
+
+// LogValue keeps the private key out of logs: only the public key is logged.
+func (s *PrivateKeySigner) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("publicKey", fmt.Sprintf("0x%x", s.publicKey)))
+}
+
+// String keeps the private key out of fmt output.
+func (s *PrivateKeySigner) String() string {
+	return fmt.Sprintf("PrivateKeySigner{publicKey: 0x%x}", s.publicKey)
+}
+
+// Sign signs msgHash with the RFC6979 deterministic Stark signer.
+func (s *PrivateKeySigner) Sign(msgHash *felt.Felt) (*big.Int, *big.Int, error) {
+	return Sign(msgHash.BigInt(new(big.Int)), s.privateKey)
+}
+
+// RemoteSigner delegates signing to a local signing daemon over HTTP or a Unix socket.
+//
+// The daemon is expected to accept POST requests with a JSON body {"hash": "0x..."}
+// and to reply with {"r": "0x...", "s": "0x..."}. Every returned signature is verified
+// against the configured public key before it is used.
+type RemoteSigner struct {
+	endpoint   string
+	publicKey  *big.Int
+	httpClient *http.Client
+}
+
+type remoteSignRequest struct {
+	Hash string `json:"hash"`
+}
+
+type remoteSignResponse struct {
+	R string `json:"r"`
+	S string `json:"s"`
+}
+
+// NewRemoteSigner creates a signer backed by a signing daemon.
+// endpoint is either an http(s) URL (e.g. "http://127.0.0.1:7070/sign") or a Unix socket
+// in the form "unix:///path/to/signer.sock" (requests go to "/sign"), optionally followed by
+// "?path=/request/path". Everything after the "unix://" scheme is the socket path, so it may contain ':'.
+func NewRemoteSigner(endpoint string, publicKey *big.Int, timeout time.Duration) (*RemoteSigner, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("signer endpoint is required")
+	}
+	if publicKey == nil {
+		return nil, fmt.Errorf("public key is required")
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+	url := endpoint
+
+	if strings.HasPrefix(endpoint, "unix://") {
+		socketPath, requestPath := strings.TrimPrefix(endpoint, "unix://"), "/sign"
+		if i := strings.LastIndex(socketPath, "?path="); i >= 0 {
+			socketPath, requestPath = socketPath[:i], socketPath[i+len("?path="):]
+		}
+		if socketPath == "" || !strings.HasPrefix(requestPath, "/") {
+			return nil, fmt.Errorf("invalid unix socket endpoint: %s", endpoint)
+		}
+		httpClient.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		}
+		url = "http://unix" + requestPath
+	}
+
+	return &RemoteSigner{
+		endpoint:   url,
+		publicKey:  new(big.Int).Set(publicKey),
+		httpClient: httpClient,
+	}, nil
+}
+
+// PublicKey returns the Stark public key the daemon signs for.
+func (s *RemoteSigner) PublicKey() *big.Int {
+	return s.publicKey
+}
+
+// Sign asks the signing daemon for a signature over msgHash and verifies it before returning.
+// The request is bounded only by the signer's timeout; use SignContext to cancel it earlier.
+func (s *RemoteSigner) Sign(msgHash *felt.Felt) (*big.Int, *big.Int, error) {
+	return s.SignContext(context.Background(), msgHash)
+}
+
+// SignContext is Sign with a request that is abandoned when ctx is done.
+func (s *RemoteSigner) SignContext(ctx context.Context, msgHash *felt.Felt) (*big.Int, *big.Int, error) {
+	hash := msgHash.BigInt(new(big.Int))
+
+	body, err := json.Marshal(remoteSignRequest{Hash: fmt.Sprintf("0x%x", hash)})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal sign request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create sign request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reach signer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read signer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("signer returned status: %d", resp.StatusCode)
+	}
+
+	var signature remoteSignResponse
+	if err := json.Unmarshal(respBody, &signature); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal signer response: %w", err)
+	}
+
+	r, ok := new(big.Int).SetString(signature.R, 0)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid signature r: %s", signature.R)
+	}
+	sig, ok := new(big.Int).SetString(signature.S, 0)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid signature s: %s", signature.S)
+	}
+
+	valid, err := curve.Verify(hash, r, sig, s.publicKey)
+	if err != nil || !valid {
+		return nil, nil, fmt.Errorf("signer returned a signature that does not verify against the public key")
+	}
+
+	return r, sig, nil
+}
@@ -0,0 +1,226 @@
+package starknet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	felt "github.com/NethermindEth/juno/core/felt"
+)
+
+const testPublicKey = "0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466"
+
+func testSigner(t *testing.T) *PrivateKeySigner {
+	t.Helper()
+	signer, err := NewPrivateKeySigner(mustBigInt(t, testPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestPrivateKeySignerPublicKey(t *testing.T) {
+	if got := fmt.Sprintf("0x%x", testSigner(t).PublicKey()); got != testPublicKey {
+		t.Errorf("public key = %s, want %s", got, testPublicKey)
+	}
+}
+
+// signingDaemon answers sign requests with a signature by key, or with tamper applied to it.
+func signingDaemon(t *testing.T, key *big.Int, tamper func(r, s *big.Int)) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body remoteSignRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		hash, _ := new(big.Int).SetString(body.Hash, 0)
+		r, s, err := Sign(hash, key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if tamper != nil {
+			tamper(r, s)
+		}
+		json.NewEncoder(w).Encode(remoteSignResponse{R: fmt.Sprintf("0x%x", r), S: fmt.Sprintf("0x%x", s)})
+	})
+}
+
+func testHash(t *testing.T) *felt.Felt {
+	t.Helper()
+	return new(felt.Felt).SetBigInt(mustBigInt(t, orderVectors[0].hash))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key := mustBigInt(t, testPrivateKey)
+	tests := []struct {
+		name    string
+		handler http.Handler
+		wantErr bool
+	}{
+		{name: "valid signature", handler: signingDaemon(t, key, nil)},
+		{name: "signature by another key", handler: signingDaemon(t, big.NewInt(12345), nil), wantErr: true},
+		{name: "tampered signature", handler: signingDaemon(t, key, func(r, s *big.Int) { s.Add(s, big.NewInt(1)) }), wantErr: true},
+		{
+			name:    "error status",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusForbidden) }),
+			wantErr: true,
+		},
+		{
+			name:    "malformed response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, `{"r":"zz","s":"0x1"}`) }),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			signer, err := NewRemoteSigner(srv.URL+"/sign", testSigner(t).PublicKey(), time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, s, err := signer.Sign(testHash(t))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprintf("0x%x", r); got != orderVectors[0].r {
+				t.Errorf("r = %s, want %s", got, orderVectors[0].r)
+			}
+			if got := fmt.Sprintf("0x%x", s); got != orderVectors[0].s {
+				t.Errorf("s = %s, want %s", got, orderVectors[0].s)
+			}
+		})
+	}
+}
+
+func TestRemoteSignerSignContextCancels(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	signer, err := NewRemoteSigner(srv.URL, testSigner(t).PublicKey(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, _, err := signer.SignContext(ctx, testHash(t)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SignContext returned after %s, want it to stop at the deadline", elapsed)
+	}
+}
+
+func TestRemoteSignerUnixSocket(t *testing.T) {
+	// The socket path contains ':' to make sure it is not mistaken for a request path
+	socketPath := filepath.Join(t.TempDir(), "sig:ner.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+
+	var gotPath string
+	daemon := signingDaemon(t, mustBigInt(t, testPrivateKey), nil)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		daemon.ServeHTTP(w, r)
+	}))
+	srv.Listener = listener
+	srv.Start()
+	defer srv.Close()
+
+	tests := []struct {
+		endpoint string
+		wantPath string
+	}{
+		{endpoint: "unix://" + socketPath, wantPath: "/sign"},
+		{endpoint: "unix://" + socketPath + "?path=/v1/stark/sign", wantPath: "/v1/stark/sign"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantPath, func(t *testing.T) {
+			signer, err := NewRemoteSigner(tt.endpoint, testSigner(t).PublicKey(), time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := signer.Sign(testHash(t)); err != nil {
+				t.Fatal(err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("request path = %s, want %s", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestNewRemoteSignerValidatesConfig(t *testing.T) {
+	publicKey := big.NewInt(1)
+	tests := []struct {
+		name      string
+		endpoint  string
+		publicKey *big.Int
+	}{
+		{name: "no endpoint", publicKey: publicKey},
+		{name: "no public key", endpoint: "http://127.0.0.1:7070/sign"},
+		{name: "no socket path", endpoint: "unix://", publicKey: publicKey},
+		{name: "relative request path", endpoint: "unix:///tmp/signer.sock?path=sign", publicKey: publicKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRemoteSigner(tt.endpoint, tt.publicKey, 0); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestAccountWithContext(t *testing.T) {
+	local, err := NewStarknetAccountWithSigner(10002, "key", testSigner(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local.WithContext(context.Background()) != local {
+		t.Error("WithContext copied an account whose signer cannot be cancelled")
+	}
+
+	remote, err := NewRemoteSigner("http://127.0.0.1:1/sign", testSigner(t).PublicKey(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := NewStarknetAccountWithSigner(10002, "key", remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bound := account.WithContext(ctx)
+	if _, _, err := bound.Sign(testHash(t)); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if bound.Signer.PublicKey().Cmp(account.PublicKey) != 0 || account.Signer != remote {
+		t.Error("WithContext changed the public key or the original account")
+	}
+}
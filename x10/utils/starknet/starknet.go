@@ -1,13 +1,14 @@
 package starknet
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"math/big"
 	"os"
 	"strconv"
 
 	felt "github.com/NethermindEth/juno/core/felt"
-	"github.com/NethermindEth/starknet.go/curve"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models"
 	"github.com/matijamarjanovic/x10xchange-go-sdk/x10/models/user"
 )
@@ -16,24 +17,41 @@ import (
 // This is the Go equivalent of Python's StarkPerpetualAccount
 type StarknetPerpetualAccount struct {
 	Vault       int
-	PrivateKey  *big.Int
 	PublicKey   *big.Int
 	APIKey      string
 	TradingFees map[string]user.TradingFee
+	Signer      Signer
 }
 
-// NewStarknetAccountFromEnv creates a StarknetAccount by loading credentials from environment variables
+// NewStarknetAccountWithSigner creates a StarknetAccount that signs through the given Signer.
+// Use this with a RemoteSigner to keep the Stark private key out of the trading process.
+func NewStarknetAccountWithSigner(vault int, apiKey string, signer Signer) (*StarknetPerpetualAccount, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+	return &StarknetPerpetualAccount{
+		Vault:       vault,
+		PublicKey:   signer.PublicKey(),
+		APIKey:      apiKey,
+		TradingFees: make(map[string]user.TradingFee),
+		Signer:      signer,
+	}, nil
+}
+
+// NewStarknetAccountFromEnv creates a StarknetAccount by loading credentials from environment variables.
+// If X10_SIGNER_URL is set, signing is delegated to that signing daemon and X10_PRIVATE_KEY is not needed.
 func NewStarknetAccount() (*StarknetPerpetualAccount, error) {
 	apiKey := os.Getenv("X10_API_KEY")
 	publicKeyHex := os.Getenv("X10_PUBLIC_KEY")
 	privateKeyHex := os.Getenv("X10_PRIVATE_KEY")
+	signerURL := os.Getenv("X10_SIGNER_URL")
 	vaultIDStr := os.Getenv("X10_VAULT_ID")
 
-	if apiKey == "" || publicKeyHex == "" || privateKeyHex == "" || vaultIDStr == "" {
+	if apiKey == "" || publicKeyHex == "" || (privateKeyHex == "" && signerURL == "") || vaultIDStr == "" {
 		return nil, &models.X10Error{
 			Code:    400,
 			Message: "Missing required environment variables",
-			Details: "Please set X10_API_KEY, X10_PUBLIC_KEY, X10_PRIVATE_KEY, and X10_VAULT_ID",
+			Details: "Please set X10_API_KEY, X10_PUBLIC_KEY, X10_VAULT_ID and either X10_PRIVATE_KEY or X10_SIGNER_URL",
 		}
 	}
 
@@ -46,15 +64,6 @@ func NewStarknetAccount() (*StarknetPerpetualAccount, error) {
 		}
 	}
 
-	privateKey, ok := new(big.Int).SetString(privateKeyHex, 0)
-	if !ok {
-		return nil, &models.X10Error{
-			Code:    400,
-			Message: "Invalid private key format",
-			Details: fmt.Sprintf("Private key must be a valid hex string: %s", privateKeyHex),
-		}
-	}
-
 	publicKey, ok := new(big.Int).SetString(publicKeyHex, 0)
 	if !ok {
 		return nil, &models.X10Error{
@@ -64,26 +73,77 @@ func NewStarknetAccount() (*StarknetPerpetualAccount, error) {
 		}
 	}
 
-	return &StarknetPerpetualAccount{
-		Vault:       vaultID,
-		PrivateKey:  privateKey,
-		PublicKey:   publicKey,
-		APIKey:      apiKey,
-		TradingFees: make(map[string]user.TradingFee),
-	}, nil
+	var signer Signer
+	if signerURL != "" {
+		signer, err = NewRemoteSigner(signerURL, publicKey, 0)
+		if err != nil {
+			return nil, &models.X10Error{
+				Code:    400,
+				Message: "Invalid signer URL",
+				Details: err.Error(),
+			}
+		}
+	} else {
+		privateKey, ok := new(big.Int).SetString(privateKeyHex, 0)
+		if !ok {
+			return nil, &models.X10Error{
+				Code:    400,
+				Message: "Invalid private key format",
+				Details: "Private key must be a valid hex string",
+			}
+		}
+
+		keySigner, err := NewPrivateKeySigner(privateKey)
+		if err != nil {
+			return nil, &models.X10Error{
+				Code:    400,
+				Message: "Invalid private key",
+				Details: err.Error(),
+			}
+		}
+		if keySigner.PublicKey().Cmp(publicKey) != 0 {
+			return nil, &models.X10Error{
+				Code:    400,
+				Message: "Key mismatch",
+				Details: "X10_PUBLIC_KEY does not match the public key derived from X10_PRIVATE_KEY",
+			}
+		}
+		signer = keySigner
+	}
+
+	return NewStarknetAccountWithSigner(vaultID, apiKey, signer)
 }
 
-// Sign signs a message hash using the account's private key
-// NOTE: curve.SignFelts uses random k, not RFC6979 deterministic k like Python
-// This may cause signature mismatches with the Python SDK
+// LogValue keeps the API key out of logs.
+func (a *StarknetPerpetualAccount) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("vault", a.Vault),
+		slog.String("publicKey", fmt.Sprintf("0x%x", a.PublicKey)),
+		slog.String("apiKey", "[REDACTED]"),
+	)
+}
+
+// Sign signs a message hash through the account's Signer.
 // Returns r, s signature components as *big.Int for easy hex formatting
 func (a *StarknetPerpetualAccount) Sign(msgHash *felt.Felt) (*big.Int, *big.Int, error) {
-	privateKeyFelt := new(felt.Felt).SetBigInt(a.PrivateKey)
-	r, s, err := curve.SignFelts(msgHash, privateKeyFelt)
+	if a.Signer == nil {
+		return nil, nil, fmt.Errorf("account has no signer")
+	}
+	r, s, err := a.Signer.Sign(msgHash)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to sign message hash: %w", err)
 	}
-	rBigInt := r.BigInt(new(big.Int))
-	sBigInt := s.BigInt(new(big.Int))
-	return rBigInt, sBigInt, nil
+	return r, s, nil
+}
+
+// WithContext returns a copy of the account whose signing is bound to ctx, so a ContextSigner
+// (e.g. a RemoteSigner) stops waiting for a signature once ctx is done. Other signers are unaffected.
+func (a *StarknetPerpetualAccount) WithContext(ctx context.Context) *StarknetPerpetualAccount {
+	signer, ok := a.Signer.(ContextSigner)
+	if !ok {
+		return a
+	}
+	bound := *a
+	bound.Signer = boundSigner{ctx: ctx, signer: signer}
+	return &bound
 }